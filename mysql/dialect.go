@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+)
+
+type dialect struct{}
+
+func NewDialect() *dialect {
+	return &dialect{}
+}
+
+func (d *dialect) QuoteIdentifier(name string) string {
+	return "`" + name + "`"
+}
+
+func (d *dialect) Placeholder(_ int) string {
+	return "?"
+}
+
+func (d *dialect) LimitOffset(limit, offset int64) string {
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+}
+
+func (d *dialect) IsDuplicateKey(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "1062")
+}
+
+func (d *dialect) InsertReturning(_ string) string {
+	return ""
+}
+
+func (d *dialect) UpsertClause(conflictKeys, updateColumns []string) string {
+	if len(updateColumns) == 0 {
+		// MySQL has no DO-NOTHING upsert short of INSERT IGNORE (a
+		// different statement shape); self-assigning a conflict key is a
+		// harmless no-op that still reports RowsAffected()==0.
+		pk := d.QuoteIdentifier(conflictKeys[0])
+		return " ON DUPLICATE KEY UPDATE " + pk + "=" + pk
+	}
+
+	sets := make([]string, len(updateColumns))
+	for i, c := range updateColumns {
+		q := d.QuoteIdentifier(c)
+		sets[i] = fmt.Sprintf("%s=VALUES(%s)", q, q)
+	}
+	return " ON DUPLICATE KEY UPDATE " + strings.Join(sets, ",")
+}
+
+func (d *dialect) UpsertReturning(_ string) string {
+	return ""
+}
+
+// datePartFuncs maps a "year"/"month"/"day"/"week_day" lookup part to the
+// MySQL function that extracts it. week_day follows MySQL's own WEEKDAY()
+// numbering (0=Monday..6=Sunday), not Django's.
+var datePartFuncs = map[string]string{
+	"year": "YEAR", "month": "MONTH", "day": "DAY", "week_day": "WEEKDAY",
+}
+
+func (d *dialect) DatePartExpr(part, columnRef string) string {
+	return fmt.Sprintf("%s(%s)", datePartFuncs[part], columnRef)
+}
+
+func (d *dialect) SupportsUpdateJoin() bool {
+	return true
+}
@@ -0,0 +1,118 @@
+package mysql
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDialect_Placeholder(t *testing.T) {
+	d := NewDialect()
+	if got := d.Placeholder(1); got != "?" {
+		t.Fatalf("Placeholder(1) = %q, want %q", got, "?")
+	}
+	if got := d.Placeholder(12); got != "?" {
+		t.Fatalf("Placeholder(12) = %q, want %q", got, "?")
+	}
+}
+
+func TestDialect_UpsertClause(t *testing.T) {
+	d := NewDialect()
+
+	cases := []struct {
+		name          string
+		conflictKeys  []string
+		updateColumns []string
+		want          string
+	}{
+		{
+			name:         "no update columns self-assigns the conflict key",
+			conflictKeys: []string{"id"},
+			want:         " ON DUPLICATE KEY UPDATE `id`=`id`",
+		},
+		{
+			name:          "update columns set against VALUES",
+			conflictKeys:  []string{"id"},
+			updateColumns: []string{"name"},
+			want:          " ON DUPLICATE KEY UPDATE `name`=VALUES(`name`)",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := d.UpsertClause(c.conflictKeys, c.updateColumns)
+			if got != c.want {
+				t.Fatalf("UpsertClause() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestDialect_UpsertReturning(t *testing.T) {
+	d := NewDialect()
+	if got := d.UpsertReturning("id"); got != "" {
+		t.Fatalf("UpsertReturning(\"id\") = %q, want empty string", got)
+	}
+}
+
+func TestDialect_DatePartExpr(t *testing.T) {
+	d := NewDialect()
+
+	cases := []struct {
+		part string
+		want string
+	}{
+		{"year", "YEAR(`created_at`)"},
+		{"week_day", "WEEKDAY(`created_at`)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.part, func(t *testing.T) {
+			got := d.DatePartExpr(c.part, "`created_at`")
+			if got != c.want {
+				t.Fatalf("DatePartExpr(%q) = %q, want %q", c.part, got, c.want)
+			}
+		})
+	}
+}
+
+func TestDialect_SupportsUpdateJoin(t *testing.T) {
+	d := NewDialect()
+	if !d.SupportsUpdateJoin() {
+		t.Fatal("SupportsUpdateJoin() = false, want true")
+	}
+}
+
+func TestDialect_IsDuplicateKey(t *testing.T) {
+	d := NewDialect()
+	if d.IsDuplicateKey(nil) {
+		t.Fatal("IsDuplicateKey(nil) = true, want false")
+	}
+	if !d.IsDuplicateKey(errors.New("Error 1062: Duplicate entry 'a' for key 'email'")) {
+		t.Fatal("IsDuplicateKey(1062 error) = false, want true")
+	}
+	if d.IsDuplicateKey(errors.New("some other error")) {
+		t.Fatal("IsDuplicateKey(unrelated error) = true, want false")
+	}
+}
+
+func TestOperator_OperatorSQL(t *testing.T) {
+	o := NewOperator()
+
+	cases := []struct {
+		op   string
+		want string
+	}{
+		{"exact", "="},
+		{"contains", "LIKE BINARY"},
+		{"regex", "REGEXP BINARY"},
+		{"iregex", "REGEXP"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.op, func(t *testing.T) {
+			if got := o.OperatorSQL(c.op); got != c.want {
+				t.Fatalf("OperatorSQL(%q) = %q, want %q", c.op, got, c.want)
+			}
+		})
+	}
+}
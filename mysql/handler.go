@@ -13,16 +13,23 @@ import (
 	"policy-center/utils"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 var ErrDuplicateKey = errors.New("duplicate key")
 
+// primaryKeyColumn is the column name every model is assumed to expose as
+// its auto-generated primary key.
+const primaryKeyColumn = "id"
+
 var _ Controller = (*Impl)(nil)
 
 type (
 	Controller interface {
 		Reset() Controller
-		Filter(filter ...map[string]any) Controller
+		// Filter accepts one or more map[string]any clauses or *db.QNode
+		// trees built with db.Q(...), ANDing them together.
+		Filter(filter ...any) Controller
 		Exclude(exclude map[string]any) Controller
 		OrderBy(orderBy any) Controller
 		Limit(pageSize, pageNum int64) Controller
@@ -31,8 +38,20 @@ type (
 		GroupBy(groupBy any) Controller
 		Insert(data map[string]any) (id int64, err error)
 		InsertModel(model any) (id int64, err error)
-		BulkInsert(data []map[string]any, handler sqlx.ResultHandler) (err error)
-		BulkInsertModel(modelSlice any, handler sqlx.ResultHandler) (err error)
+		// BulkInsert writes data in batches of BatchSize rows per statement,
+		// applying conflict (nil for a plain insert) to every batch, and
+		// returns the summed RowsAffected across those statements. With a
+		// plain insert or an Ignore conflict this equals the row count
+		// actually written; with an UpdateColumns conflict, MySQL counts
+		// each row that collided and was updated as 2 rather than 1, so
+		// affected can overcount — use handler for an exact split there.
+		BulkInsert(data []map[string]any, conflict *OnConflict, handler sqlx.ResultHandler) (affected int64, err error)
+		BulkInsertModel(modelSlice any, conflict *OnConflict, handler sqlx.ResultHandler) (affected int64, err error)
+		// BatchSize sets how many rows BulkInsert/BulkInsertModel pack into
+		// a single multi-row INSERT statement (default defaultBulkBatchSize),
+		// trading a larger statement (bounded by the server's
+		// max_allowed_packet) for fewer round trips.
+		BatchSize(n int) Controller
 		Remove() (num int64, err error)
 		Update(data map[string]any) (num int64, err error)
 		Count() (num int64, err error)
@@ -44,25 +63,224 @@ type (
 		Modify(data map[string]any) (num int64, err error)
 		Exist() (exist bool, error error)
 		List() (num int64, data []map[string]any, err error)
-		GetOrCreate(data map[string]any) (result map[string]any, err error)
-		CreateOrUpdate(filter map[string]any, data map[string]any) (created bool, num int64, err error)
+		// GetOrCreate, CreateOrUpdate and CreateIfNotExist take conflictKeys
+		// explicitly — the columns a real unique index covers — rather than
+		// guessing them from the data/filter map, since an arbitrary column
+		// set only works as an ON CONFLICT target by coincidence. An empty
+		// conflictKeys falls back to the original multi-roundtrip
+		// select-then-insert-or-update path, which needs no unique index.
+		GetOrCreate(conflictKeys []string, data map[string]any) (result map[string]any, err error)
+		// On the update branch (a non-empty conflictKeys), num is the
+		// conflicting statement's raw affected-row count, which on MySQL is
+		// 2 when the matched row changed and 0 when its columns were already
+		// equal to data — that 0 still means a row was found and matched,
+		// not that none was.
+		CreateOrUpdate(conflictKeys []string, filter map[string]any, data map[string]any) (created bool, num int64, err error)
 		GetC2CMap(column1, column2 string) (res map[any]any, err error)
-		CreateIfNotExist(data map[string]any) (id int64, created bool, err error)
+		CreateIfNotExist(conflictKeys []string, data map[string]any) (id int64, created bool, err error)
+		Transaction(ctx context.Context, fn func(txCtl Controller) error) (err error)
+		// Upsert's rows is the statement's raw RowsAffected/RETURNING count:
+		// 1 on Postgres/SQLite whenever DO UPDATE ran, but on MySQL 0 if the
+		// ON DUPLICATE KEY UPDATE changed nothing and 2 (not 1) if it did.
+		Upsert(conflictKeys []string, insert map[string]any, update map[string]any) (id int64, created bool, rows int64, err error)
+		// Preload marks each of paths (a tag-declared relation name, e.g.
+		// "user") to be eager-loaded with the next FindOne/FindOneModel/
+		// FindAll/FindAllModel call.
+		Preload(paths ...string) Controller
+		// PreloadWith is Preload for a single path, with fn given the chance
+		// to constrain the preloaded query, e.g. Filter out soft-deleted rows.
+		PreloadWith(path string, fn func(Controller) Controller) Controller
 	}
 
 	Impl struct {
 		context      context.Context
-		conn         sqlx.SqlConn
+		conn         sqlx.Session
+		sqlConn      sqlx.SqlConn
 		model        any
 		modelSlice   any
 		table        string
+		quotedTable  string
 		fieldNameMap map[string]struct{}
 		fieldRows    string
 		mTag         string
+		dialect      db.Dialect
 		qs           db.QuerySet
+		relationCfg  db.RelationConfig
+		preloads     []preloadSpec
+		batchSize    int
 	}
 )
 
+// defaultBulkBatchSize is how many rows BulkInsert/BulkInsertModel pack into
+// one multi-row INSERT statement when BatchSize hasn't been called,
+// chosen to keep the statement well under MySQL's default 4MB
+// max_allowed_packet for typical row widths.
+const defaultBulkBatchSize = 1000
+
+// OnConflict selects how BulkInsert/BulkInsertModel handle a row that
+// collides with Keys: Ignore drops it, UpdateColumns upserts the given
+// columns instead. Both render through Dialect.UpsertClause, the same
+// rendering Upsert uses — Ignore is simply UpdateColumns with no columns,
+// i.e. UpsertClause's own no-op-update fallback, so the package has a
+// single conflict-rendering code path instead of MySQL's INSERT IGNORE
+// keyword plus a separate ON CONFLICT DO NOTHING for Postgres/SQLite.
+type OnConflict struct {
+	Keys    []string
+	Columns []string
+}
+
+// Ignore builds an OnConflict that drops rows colliding on conflictKeys.
+// conflictKeys must name the columns a real unique index covers exactly;
+// Ignore panics if it is empty, since BulkInsert would otherwise panic on
+// MySQL and render invalid SQL on Postgres/SQLite.
+func Ignore(conflictKeys []string) *OnConflict {
+	if len(conflictKeys) == 0 {
+		panic("zorm: Ignore requires at least one conflict key")
+	}
+	return &OnConflict{Keys: conflictKeys}
+}
+
+// UpdateColumns builds an OnConflict that upserts columns on rows colliding
+// on conflictKeys. conflictKeys must name the columns a real unique index
+// covers exactly; UpdateColumns panics if it is empty, since BulkInsert
+// would otherwise panic on MySQL and render invalid SQL on Postgres/SQLite.
+func UpdateColumns(conflictKeys, columns []string) *OnConflict {
+	if len(conflictKeys) == 0 {
+		panic("zorm: UpdateColumns requires at least one conflict key")
+	}
+	return &OnConflict{Keys: conflictKeys, Columns: columns}
+}
+
+// preloadSpec is one relation path registered via Preload/PreloadWith,
+// together with the optional callback PreloadWith uses to constrain it.
+type preloadSpec struct {
+	path   string
+	filter func(Controller) Controller
+}
+
+// modelRegistration is the metadata NewController/NewTxController capture
+// for a model, keyed by its (un-shifted) struct name in modelRegistry, so
+// that both relation-path joins (resolveRelations) and Preload can work
+// with any previously-registered model, not just the one a query was built
+// against.
+type modelRegistration struct {
+	tableName    string
+	quotedTable  string
+	fieldRows    string
+	fieldNameMap map[string]struct{}
+	relations    db.RelationMap
+	model        any
+	modelSlice   any
+	dialect      db.Dialect
+	mTag         string
+}
+
+var modelRegistry sync.Map
+
+// parseRelations reflects over m's struct fields for zorm:"fk=Col,to=Model"
+// tags, naming each relation after the lowercased field name that carries it,
+// e.g. a field "User" tagged zorm:"fk=user_id,to=User" resolves the "user"
+// hop of a "user__name" path. A slice-typed field declares a has-many
+// relation (Relation.Many), a single pointer field a belongs-to relation.
+// An optional "join=inner" part (default "left") picks the JOIN type
+// registerJoin renders for that hop, e.g. zorm:"fk=user_id,to=User,join=inner"
+// for a required, non-nullable FK.
+func parseRelations(m any) db.RelationMap {
+	t := reflect.TypeOf(m).Elem()
+	relations := make(db.RelationMap)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("zorm")
+		if tag == "" {
+			continue
+		}
+
+		var fk, to, join string
+		for _, part := range strings.Split(tag, ",") {
+			kv := strings.SplitN(part, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			switch kv[0] {
+			case "fk":
+				fk = kv[1]
+			case "to":
+				to = kv[1]
+			case "join":
+				join = kv[1]
+			}
+		}
+		if fk == "" || to == "" {
+			continue
+		}
+
+		joinType := "LEFT"
+		if strings.EqualFold(join, "inner") {
+			joinType = "INNER"
+		}
+
+		relations[strings.ToLower(field.Name)] = db.Relation{
+			FK:        fk,
+			To:        to,
+			Table:     shiftName(to),
+			FieldName: field.Name,
+			Many:      field.Type.Kind() == reflect.Slice,
+			JoinType:  joinType,
+		}
+	}
+
+	return relations
+}
+
+// resolveRelations looks up the relations registered for modelName by a
+// prior NewController/NewTxController call, for resolving hops beyond the
+// first in a "__"-separated relation path.
+func resolveRelations(modelName string) db.RelationMap {
+	if reg, ok := lookupModel(modelName); ok {
+		return reg.relations
+	}
+	return nil
+}
+
+// lookupModel returns the registration stored under modelName by a prior
+// NewController/NewTxController call.
+func lookupModel(modelName string) (*modelRegistration, bool) {
+	v, ok := modelRegistry.Load(modelName)
+	if !ok {
+		return nil, false
+	}
+	return v.(*modelRegistration), true
+}
+
+// fieldByColumn finds the struct field of t whose shifted name is column,
+// e.g. column "user_id" matches a field named UserID.
+func fieldByColumn(t reflect.Type, column string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if shiftName(f.Name) == column {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// isZeroValue reports whether v is nil or its type's zero value, used to
+// skip parents with no value to match a preloaded relation by.
+func isZeroValue(v any) bool {
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
+
+// txStateKey is the context key under which Transaction stashes the current
+// transaction's session and savepoint depth, so a nested Transaction call
+// knows to open a SAVEPOINT instead of starting a new transaction.
+type txStateKey struct{}
+
+type txState struct {
+	session sqlx.Session
+	depth   int
+}
+
 // shiftName shift name like DevicePolicyMap to device_policy_map
 func shiftName(s string) string {
 	res := ""
@@ -76,30 +294,61 @@ func shiftName(s string) string {
 			res += string(c)
 		}
 	}
-	return "`" + res + "`"
+	return res
 }
 
-func NewController(conn sqlx.SqlConn, m any, mSlice any) func(ctx context.Context) Controller {
+// modelMeta builds the table/field metadata shared by NewController and
+// NewTxController, and registers m's declared relations under its struct
+// name so later queries against other models can resolve joins through it.
+func modelMeta(m, mSlice any, dialect db.Dialect) (tableName, quotedTable, fieldRows string, fieldNameMap map[string]struct{}, relations db.RelationMap) {
 	t := reflect.TypeOf(m)
 	if t.Kind() != reflect.Ptr {
 		log.Panicf("model [%s] must be a pointer", t.Name())
-		return nil
+		return
 	}
 	name := t.Elem().Name()
 
 	ts := reflect.TypeOf(mSlice)
 	if ts.Kind() != reflect.Ptr || ts.Elem().Kind() != reflect.Slice || ts.Elem().Elem().Kind() != reflect.Ptr {
 		log.Panicf("model Slice [%s] must be a pointer", ts.Name())
-		return nil
+		return
 	}
 
 	if ts.Elem().Elem().Elem() != t.Elem() {
 		log.Panicf("model Slice not equal to model")
 	}
 
-	tableName := shiftName(name)
-	fieldNameMap := utils.StrSlice2Map(builder.RawFieldNames(m, true))
-	fieldRows := strings.Join(builder.RawFieldNames(m), ",")
+	tableName = shiftName(name)
+	quotedTable = dialect.QuoteIdentifier(tableName)
+	fieldNameMap = utils.StrSlice2Map(builder.RawFieldNames(m, true))
+	fieldRows = strings.Join(builder.RawFieldNames(m), ",")
+	relations = parseRelations(m)
+
+	modelRegistry.Store(name, &modelRegistration{
+		tableName:    tableName,
+		quotedTable:  quotedTable,
+		fieldRows:    fieldRows,
+		fieldNameMap: fieldNameMap,
+		relations:    relations,
+		model:        m,
+		modelSlice:   mSlice,
+		dialect:      dialect,
+		mTag:         flag.DefaultModelTag,
+	})
+	return
+}
+
+// NewController builds a Controller factory for model m (and its slice type
+// mSlice) against conn, rendering SQL for the given dialect. Pass
+// mysql.NewDialect(), postgres.NewDialect() or sqlite.NewDialect() to target
+// that engine.
+func NewController(conn sqlx.SqlConn, m any, mSlice any, dialect db.Dialect) func(ctx context.Context) Controller {
+	tableName, quotedTable, fieldRows, fieldNameMap, relations := modelMeta(m, mSlice, dialect)
+	relationCfg := db.RelationConfig{
+		BaseTable: db.BaseAlias,
+		Relations: relations,
+		Resolve:   resolveRelations,
+	}
 
 	return func(ctx context.Context) Controller {
 		if ctx == nil {
@@ -108,13 +357,53 @@ func NewController(conn sqlx.SqlConn, m any, mSlice any) func(ctx context.Contex
 		return &Impl{
 			context:      ctx,
 			conn:         conn,
+			sqlConn:      conn,
 			model:        m,
 			modelSlice:   mSlice,
 			table:        tableName,
+			quotedTable:  quotedTable,
 			fieldNameMap: fieldNameMap,
 			fieldRows:    fieldRows,
 			mTag:         flag.DefaultModelTag,
-			qs:           db.NewQuerySet(NewOperator()),
+			dialect:      dialect,
+			relationCfg:  relationCfg,
+			qs:           db.NewQuerySet(NewOperator(), dialect, relationCfg),
+		}
+	}
+}
+
+// NewTxController builds a Controller factory bound to an already-open
+// transaction session, e.g. one handed to another model's Transaction
+// callback. Calling Transaction again on a Controller built this way opens a
+// SAVEPOINT rather than a brand new transaction.
+func NewTxController(session sqlx.Session, m any, mSlice any, dialect db.Dialect) func(ctx context.Context) Controller {
+	tableName, quotedTable, fieldRows, fieldNameMap, relations := modelMeta(m, mSlice, dialect)
+	relationCfg := db.RelationConfig{
+		BaseTable: db.BaseAlias,
+		Relations: relations,
+		Resolve:   resolveRelations,
+	}
+
+	return func(ctx context.Context) Controller {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if _, ok := ctx.Value(txStateKey{}).(*txState); !ok {
+			ctx = context.WithValue(ctx, txStateKey{}, &txState{session: session})
+		}
+		return &Impl{
+			context:      ctx,
+			conn:         session,
+			model:        m,
+			modelSlice:   mSlice,
+			table:        tableName,
+			quotedTable:  quotedTable,
+			fieldNameMap: fieldNameMap,
+			fieldRows:    fieldRows,
+			mTag:         flag.DefaultModelTag,
+			dialect:      dialect,
+			relationCfg:  relationCfg,
+			qs:           db.NewQuerySet(NewOperator(), dialect, relationCfg),
 		}
 	}
 }
@@ -131,7 +420,7 @@ func (m *Impl) values(values []string) string {
 			logc.Errorf(m.ctx(), "Key [%s] not exist.", v)
 			continue
 		}
-		valueRows += fmt.Sprintf("`%s`,", v)
+		valueRows += m.dialect.QuoteIdentifier(v) + ","
 	}
 	valueRows = strings.TrimRight(valueRows, ",")
 
@@ -139,12 +428,31 @@ func (m *Impl) values(values []string) string {
 }
 
 func (m *Impl) Reset() Controller {
-	m.qs = db.NewQuerySet(NewOperator())
+	m.qs = db.NewQuerySet(NewOperator(), m.dialect, m.relationCfg)
+	m.preloads = nil
+	return m
+}
+
+func (m *Impl) Preload(paths ...string) Controller {
+	for _, path := range paths {
+		m.preloads = append(m.preloads, preloadSpec{path: path})
+	}
+	return m
+}
+
+func (m *Impl) PreloadWith(path string, fn func(Controller) Controller) Controller {
+	for i := range m.preloads {
+		if m.preloads[i].path == path {
+			m.preloads[i].filter = fn
+			return m
+		}
+	}
+	m.preloads = append(m.preloads, preloadSpec{path: path, filter: fn})
 	return m
 }
 
-func (m *Impl) Filter(filter ...map[string]any) Controller {
-	m.qs.FilterToSQL(filter)
+func (m *Impl) Filter(filter ...any) Controller {
+	m.qs.FilterToSQL(filter...)
 	return m
 }
 
@@ -182,20 +490,21 @@ func (m *Impl) OrderBy(orderBy any) Controller {
 
 	for _, by := range orderBySlice {
 		by = strings.TrimSpace(by)
-		if strings.HasPrefix(by, "-") {
-			if _, ok := m.fieldNameMap[by[1:]]; ok {
-				orderByChecked = append(orderByChecked, by)
-			} else {
-				logc.Errorf(m.ctx(), "Order by key [%s] not exist.", by[1:])
-				continue
-			}
+		key := strings.TrimPrefix(by, "-")
+		// A "__" path crosses into a related model's fields, which
+		// fieldNameMap (this model's own columns) can't validate, so it is
+		// passed on unchecked: OrderByToSQL follows its declared relation
+		// hops and orders by the first segment that is not one, ignoring
+		// any segments after it.
+		if strings.Contains(key, "__") {
+			orderByChecked = append(orderByChecked, by)
+			continue
+		}
+		if _, ok := m.fieldNameMap[key]; ok {
+			orderByChecked = append(orderByChecked, by)
 		} else {
-			if _, ok := m.fieldNameMap[by]; ok {
-				orderByChecked = append(orderByChecked, by)
-			} else {
-				logc.Errorf(m.ctx(), "Order by key [%s] not exist.", by)
-				continue
-			}
+			logc.Errorf(m.ctx(), "Order by key [%s] not exist.", key)
+			continue
 		}
 	}
 
@@ -284,83 +593,143 @@ func (m *Impl) GroupBy(groupBy any) Controller {
 
 func (m *Impl) Insert(data map[string]any) (id int64, err error) {
 	var (
-		rows []string
-		args []any
+		rows         []string
+		placeholders []string
+		args         []any
 	)
 
 	for k, _ := range m.fieldNameMap {
 		if _, ok := data[k]; !ok {
 			continue
 		}
-		rows = append(rows, fmt.Sprintf("`%s`", k))
+		rows = append(rows, m.dialect.QuoteIdentifier(k))
+		placeholders = append(placeholders, m.dialect.Placeholder(len(placeholders)+1))
 		args = append(args, data[k])
 	}
 
-	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", m.table, strings.Join(rows, ","), strings.Repeat("?,", len(rows)-1)+"?")
+	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", m.quotedTable, strings.Join(rows, ","), strings.Join(placeholders, ","))
+
+	returning := m.dialect.InsertReturning(primaryKeyColumn)
+	if returning == "" {
+		res, err := m.conn.ExecCtx(m.ctx(), sql, args...)
+		if err != nil {
+			if m.dialect.IsDuplicateKey(err) {
+				return 0, ErrDuplicateKey
+			}
+			logc.Errorf(m.ctx(), "Insert error: %s", err)
+			return 0, err
+		}
+
+		id, err = res.LastInsertId()
+		if err != nil {
+			logc.Errorf(m.ctx(), "Get last insert id error: %s", err)
+		}
+
+		return id, err
+	}
 
-	res, err := m.conn.ExecCtx(m.ctx(), sql, args...)
+	err = m.conn.QueryRowCtx(m.ctx(), &id, sql+returning, args...)
 	if err != nil {
-		if strings.Contains(err.Error(), "1062") {
+		if m.dialect.IsDuplicateKey(err) {
 			return 0, ErrDuplicateKey
 		}
 		logc.Errorf(m.ctx(), "Insert error: %s", err)
 		return 0, err
 	}
 
-	id, err = res.LastInsertId()
-	if err != nil {
-		logc.Errorf(m.ctx(), "Get last insert id error: %s", err)
-	}
-
-	return id, err
+	return id, nil
 }
 
 func (m *Impl) InsertModel(model any) (id int64, err error) {
 	return m.Insert(utils.Struct2Map(model, m.mTag))
 }
 
-func (m *Impl) BulkInsert(data []map[string]any, handler sqlx.ResultHandler) (err error) {
+// BatchSize sets how many rows BulkInsert/BulkInsertModel pack into a single
+// multi-row INSERT statement; n <= 0 falls back to defaultBulkBatchSize.
+func (m *Impl) BatchSize(n int) Controller {
+	m.batchSize = n
+	return m
+}
+
+func (m *Impl) bulkBatchSize() int {
+	if m.batchSize > 0 {
+		return m.batchSize
+	}
+	return defaultBulkBatchSize
+}
+
+func (m *Impl) BulkInsert(data []map[string]any, conflict *OnConflict, handler sqlx.ResultHandler) (affected int64, err error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
 	var rows []string
 	for k, _ := range m.fieldNameMap {
 		if _, ok := data[0][k]; !ok {
 			continue
 		}
-		rows = append(rows, fmt.Sprintf("`%s`", k))
+		rows = append(rows, k)
 	}
 
-	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", m.table, strings.Join(rows, ","), strings.Repeat("?,", len(rows)-1)+"?")
+	quoted := make([]string, len(rows))
+	for i, k := range rows {
+		quoted[i] = m.dialect.QuoteIdentifier(k)
+	}
+	rowPlaceholder := "(" + strings.TrimRight(strings.Repeat("?,", len(rows)), ",") + ")"
 
-	blk, err := sqlx.NewBulkInserter(m.conn, sql)
-	if err != nil {
-		logc.Errorf(m.ctx(), "Insert BulkInsert handle error: %+v", err)
-		return err
+	var conflictSQL string
+	if conflict != nil {
+		conflictSQL = m.dialect.UpsertClause(conflict.Keys, conflict.Columns)
 	}
-	defer blk.Flush()
 
-	for _, v := range data {
+	batchSize := m.bulkBatchSize()
+	for start := 0; start < len(data); start += batchSize {
+		end := start + batchSize
+		if end > len(data) {
+			end = len(data)
+		}
+		batch := data[start:end]
+
+		sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s%s", m.quotedTable, strings.Join(quoted, ","),
+			strings.TrimRight(strings.Repeat(rowPlaceholder+",", len(batch)), ","), conflictSQL)
+
 		var args []any
-		for _, k := range rows {
-			args = append(args, v[k])
+		for _, v := range batch {
+			for _, k := range rows {
+				args = append(args, v[k])
+			}
+		}
+		for i := 1; i <= len(args); i++ {
+			sql = strings.Replace(sql, "?", m.dialect.Placeholder(i), 1)
 		}
-		if err := blk.Insert(args...); err != nil {
+
+		res, err := m.conn.ExecCtx(m.ctx(), sql, args...)
+		if err != nil {
 			logc.Errorf(m.ctx(), "BulkInsert error: %+v", err)
-			return err
+			return affected, err
 		}
-	}
 
-	if handler != nil {
-		blk.SetResultHandler(handler)
+		if handler != nil {
+			handler(res, err)
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			logc.Errorf(m.ctx(), "Get rows affected error: %s", err)
+			return affected, err
+		}
+		affected += n
 	}
 
-	return nil
+	return affected, nil
 }
 
-func (m *Impl) BulkInsertModel(modelSlice any, handler sqlx.ResultHandler) (err error) {
-	return nil
+func (m *Impl) BulkInsertModel(modelSlice any, conflict *OnConflict, handler sqlx.ResultHandler) (affected int64, err error) {
+	return m.BulkInsert(utils.StructSlice2MapSlice(modelSlice, m.mTag), conflict, handler)
 }
 
 func (m *Impl) Remove() (num int64, err error) {
-	sql := fmt.Sprintf("DELETE FROM %s", m.table)
+	sql := fmt.Sprintf("DELETE FROM %s", m.quotedTable)
 
 	filterSQL, filterArgs := m.qs.GetQuerySet()
 	sql += filterSQL
@@ -379,6 +748,47 @@ func (m *Impl) Remove() (num int64, err error) {
 	return num, nil
 }
 
+// baseFromClause renders the base table's FROM fragment, aliasing it to
+// db.BaseAlias whenever a relation is joined into this query so SELECT/
+// WHERE/ORDER BY/UPDATE SET can unambiguously qualify base-table columns
+// that collide with a joined table's (resolveFieldRef does the same for
+// fields referenced through Filter/Exclude/OrderBy and "__" paths in
+// Select; Select columns without "__" are passed through unqualified).
+func (m *Impl) baseFromClause() string {
+	if m.qs.GetJoinSQL() == "" {
+		return m.quotedTable
+	}
+	return m.quotedTable + " AS " + db.BaseAlias
+}
+
+// baseColumnRef quotes name and, if a relation is joined into this query,
+// qualifies it with the base-table alias.
+func (m *Impl) baseColumnRef(name string) string {
+	if m.qs.GetJoinSQL() == "" {
+		return m.dialect.QuoteIdentifier(name)
+	}
+	return db.BaseAlias + "." + m.dialect.QuoteIdentifier(name)
+}
+
+// baseSelectColumns returns m.fieldRows, qualified with the base-table alias
+// if a relation is joined into this query, and prefixed with DISTINCT if
+// any of those relations is has-many (a to-many join duplicates each base
+// row once per matching child row, so the result needs de-duplicating).
+func (m *Impl) baseSelectColumns() string {
+	cols := m.fieldRows
+	if m.qs.GetJoinSQL() != "" {
+		rows := strings.Split(m.fieldRows, ",")
+		for i, c := range rows {
+			rows[i] = db.BaseAlias + "." + c
+		}
+		cols = strings.Join(rows, ",")
+	}
+	if m.qs.HasToManyJoin() {
+		cols = "DISTINCT " + cols
+	}
+	return cols
+}
+
 func (m *Impl) Update(data map[string]any) (num int64, err error) {
 	var (
 		args       []any
@@ -391,13 +801,21 @@ func (m *Impl) Update(data map[string]any) (num int64, err error) {
 			logc.Errorf(m.ctx(), "Key [%s] not exist.", k)
 			continue
 		}
-		updateRows = append(updateRows, fmt.Sprintf("`%s`", k))
+		updateRows = append(updateRows, fmt.Sprintf("%s=%s", m.baseColumnRef(k), m.dialect.Placeholder(len(updateRows)+1)))
 		updateArgs = append(updateArgs, v)
 	}
 
-	sql := fmt.Sprintf("UPDATE %s SET %s", m.table, strings.Join(updateRows, "=?,")+"=?")
+	joinSQL := m.qs.GetJoinSQL()
+	if joinSQL != "" && !m.dialect.SupportsUpdateJoin() {
+		return 0, fmt.Errorf("zorm: Update across a joined relation path is not supported on this dialect")
+	}
+
+	sql := fmt.Sprintf("UPDATE %s", m.baseFromClause())
+	sql += joinSQL
+	sql += fmt.Sprintf(" SET %s", strings.Join(updateRows, ","))
 	args = append(args, updateArgs...)
 
+	m.qs.SetParamOffset(len(updateRows))
 	filterSQL, filterArgs := m.qs.GetQuerySet()
 	sql += filterSQL
 	args = append(args, filterArgs...)
@@ -417,7 +835,15 @@ func (m *Impl) Update(data map[string]any) (num int64, err error) {
 }
 
 func (m *Impl) Count() (num int64, err error) {
-	query := fmt.Sprintf("SELECT count(1) FROM %s", m.table)
+	countExpr := "count(1)"
+	if m.qs.HasToManyJoin() {
+		// A has-many join duplicates each base row once per matching child
+		// row, so a plain count(1) would overcount; count the distinct
+		// base primary key instead.
+		countExpr = "count(DISTINCT " + m.baseColumnRef(primaryKeyColumn) + ")"
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s", countExpr, m.baseFromClause())
+	query += m.qs.GetJoinSQL()
 
 	filterSQL, filterArgs := m.qs.GetQuerySet()
 
@@ -441,12 +867,14 @@ func (m *Impl) FindOne() (result map[string]any, err error) {
 	query := "SELECT %s FROM %s"
 
 	selectRows := m.qs.GetSelectSQL()
-	if selectRows != "*" {
-		query = fmt.Sprintf(query, selectRows, m.table)
-	} else {
-		query = fmt.Sprintf(query, m.fieldRows, m.table)
+	if selectRows == "*" {
+		selectRows = m.baseSelectColumns()
+	} else if m.qs.HasToManyJoin() {
+		selectRows = "DISTINCT " + selectRows
 	}
+	query = fmt.Sprintf(query, selectRows, m.baseFromClause())
 
+	query += m.qs.GetJoinSQL()
 	filterSQL, filterArgs := m.qs.GetQuerySet()
 
 	query += filterSQL
@@ -460,7 +888,13 @@ func (m *Impl) FindOne() (result map[string]any, err error) {
 
 	switch {
 	case err == nil:
-		return utils.Struct2Map(res, m.mTag), nil
+		row := utils.Struct2Map(res, m.mTag)
+		if len(m.preloads) > 0 {
+			if err = m.runPreloadRows([]map[string]any{row}); err != nil {
+				return nil, err
+			}
+		}
+		return row, nil
 	case errors.Is(err, sqlx.ErrNotFound):
 		return map[string]any{}, nil
 	default:
@@ -473,12 +907,14 @@ func (m *Impl) FindOneModel(modelPtr any) (err error) {
 	query := "SELECT %s FROM %s"
 
 	selectRows := m.qs.GetSelectSQL()
-	if selectRows != "*" {
-		query = fmt.Sprintf(query, selectRows, m.table)
-	} else {
-		query = fmt.Sprintf(query, m.fieldRows, m.table)
+	if selectRows == "*" {
+		selectRows = m.baseSelectColumns()
+	} else if m.qs.HasToManyJoin() {
+		selectRows = "DISTINCT " + selectRows
 	}
+	query = fmt.Sprintf(query, selectRows, m.baseFromClause())
 
+	query += m.qs.GetJoinSQL()
 	filterSQL, filterArgs := m.qs.GetQuerySet()
 
 	query += filterSQL
@@ -490,6 +926,9 @@ func (m *Impl) FindOneModel(modelPtr any) (err error) {
 
 	switch {
 	case err == nil:
+		if len(m.preloads) > 0 {
+			return m.runPreloadOne(modelPtr)
+		}
 		return nil
 	case errors.Is(err, sqlx.ErrNotFound):
 		return sqlx.ErrNotFound
@@ -503,12 +942,14 @@ func (m *Impl) FindAll() (result []map[string]any, err error) {
 	query := "SELECT %s FROM %s"
 
 	selectRows := m.qs.GetSelectSQL()
-	if selectRows != "*" {
-		query = fmt.Sprintf(query, selectRows, m.table)
-	} else {
-		query = fmt.Sprintf(query, m.fieldRows, m.table)
+	if selectRows == "*" {
+		selectRows = m.baseSelectColumns()
+	} else if m.qs.HasToManyJoin() {
+		selectRows = "DISTINCT " + selectRows
 	}
+	query = fmt.Sprintf(query, selectRows, m.baseFromClause())
 
+	query += m.qs.GetJoinSQL()
 	filterSQL, filterArgs := m.qs.GetQuerySet()
 
 	query += filterSQL
@@ -522,7 +963,13 @@ func (m *Impl) FindAll() (result []map[string]any, err error) {
 
 	switch {
 	case err == nil:
-		return utils.StructSlice2MapSlice(res, m.mTag), nil
+		rows := utils.StructSlice2MapSlice(res, m.mTag)
+		if len(m.preloads) > 0 {
+			if err = m.runPreloadRows(rows); err != nil {
+				return nil, err
+			}
+		}
+		return rows, nil
 	case errors.Is(err, sqlx.ErrNotFound):
 		return []map[string]any{}, nil
 	default:
@@ -535,12 +982,14 @@ func (m *Impl) FindAllModel(modelSlicePtr any) (err error) {
 	query := "SELECT %s FROM %s"
 
 	selectRows := m.qs.GetSelectSQL()
-	if selectRows != "*" {
-		query = fmt.Sprintf(query, selectRows, m.table)
-	} else {
-		query = fmt.Sprintf(query, m.fieldRows, m.table)
+	if selectRows == "*" {
+		selectRows = m.baseSelectColumns()
+	} else if m.qs.HasToManyJoin() {
+		selectRows = "DISTINCT " + selectRows
 	}
+	query = fmt.Sprintf(query, selectRows, m.baseFromClause())
 
+	query += m.qs.GetJoinSQL()
 	filterSQL, filterArgs := m.qs.GetQuerySet()
 
 	query += filterSQL
@@ -557,6 +1006,9 @@ func (m *Impl) FindAllModel(modelSlicePtr any) (err error) {
 	case reflect.ValueOf(modelSlicePtr).Elem().Len() == 0:
 		return sqlx.ErrNotFound
 	default:
+		if len(m.preloads) > 0 {
+			return m.runPreloadModels(modelSlicePtr)
+		}
 		return nil
 	}
 }
@@ -593,32 +1045,139 @@ func (m *Impl) List() (total int64, data []map[string]any, err error) {
 	return total, data, nil
 }
 
-func (m *Impl) GetOrCreate(data map[string]any) (map[string]any, error) {
-	if _, err := m.Insert(data); err != nil {
-		if !errors.Is(err, ErrDuplicateKey) {
-			return nil, err
+// Upsert emits a single dialect-appropriate statement that inserts a row or,
+// on conflict with conflictKeys, updates it with update instead: MySQL's
+// "INSERT ... ON DUPLICATE KEY UPDATE ...", Postgres's "INSERT ... ON
+// CONFLICT (...) DO UPDATE SET ... RETURNING id", and SQLite's same ON
+// CONFLICT statement without RETURNING (id and created then come from
+// LastInsertId and RowsAffected, as on MySQL). created reports whether
+// the row was freshly inserted rather than updated; rows is the statement's
+// raw affected-row count (see the Controller.Upsert doc for its MySQL
+// quirk). conflictKeys must name columns a real unique index covers exactly
+// — Postgres/SQLite reject an ON CONFLICT target that doesn't match one.
+func (m *Impl) Upsert(conflictKeys []string, insert map[string]any, update map[string]any) (id int64, created bool, rows int64, err error) {
+	if len(conflictKeys) == 0 {
+		return 0, false, 0, errors.New("zorm: Upsert requires at least one conflict key")
+	}
+
+	var (
+		cols         []string
+		placeholders []string
+		args         []any
+	)
+	for k := range m.fieldNameMap {
+		v, ok := insert[k]
+		if !ok {
+			continue
 		}
+		cols = append(cols, m.dialect.QuoteIdentifier(k))
+		placeholders = append(placeholders, m.dialect.Placeholder(len(placeholders)+1))
+		args = append(args, v)
+	}
+
+	updateCols := make([]string, 0, len(update))
+	for k := range update {
+		if _, ok := m.fieldNameMap[k]; !ok {
+			continue
+		}
+		updateCols = append(updateCols, k)
+	}
+
+	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)%s", m.quotedTable, strings.Join(cols, ","), strings.Join(placeholders, ","), m.dialect.UpsertClause(conflictKeys, updateCols))
+
+	returning := m.dialect.UpsertReturning(primaryKeyColumn)
+	if returning == "" {
+		res, err := m.conn.ExecCtx(m.ctx(), sql, args...)
+		if err != nil {
+			logc.Errorf(m.ctx(), "Upsert error: %+v", err)
+			return 0, false, 0, err
+		}
+
+		id, err = res.LastInsertId()
+		if err != nil {
+			logc.Errorf(m.ctx(), "Get last insert id error: %s", err)
+		}
+
+		rows, err = res.RowsAffected()
+		if err != nil {
+			logc.Errorf(m.ctx(), "Upsert rows affected error: %s", err)
+		}
+		return id, rows == 1, rows, nil
+	}
+
+	var scanned struct {
+		ID      int64 `db:"id"`
+		Created bool  `db:"zorm_created"`
+	}
+	if err = m.conn.QueryRowCtx(m.ctx(), &scanned, sql+returning, args...); err != nil {
+		return 0, false, 0, err
+	}
+
+	return scanned.ID, scanned.Created, 1, nil
+}
+
+func (m *Impl) GetOrCreate(conflictKeys []string, data map[string]any) (map[string]any, error) {
+	if len(conflictKeys) == 0 {
+		// Fallback: no known unique index to target a conflict with, so
+		// fall back to the original insert-then-select path.
+		if _, err := m.Insert(data); err != nil {
+			if !errors.Is(err, ErrDuplicateKey) {
+				return nil, err
+			}
+		}
+		return m.Filter(data).FindOne()
+	}
+
+	if _, _, _, err := m.Upsert(conflictKeys, data, nil); err != nil && !errors.Is(err, sqlx.ErrNotFound) {
+		return nil, err
 	}
 
 	return m.Filter(data).FindOne()
 }
 
-func (m *Impl) CreateOrUpdate(filter map[string]any, data map[string]any) (bool, int64, error) {
-	if exist, err := m.Filter(filter).Exist(); err != nil {
-		return false, 0, err
-	} else if exist {
-		if num, err := m.Filter(filter).Update(data); err != nil {
+func (m *Impl) CreateOrUpdate(conflictKeys []string, filter map[string]any, data map[string]any) (bool, int64, error) {
+	if len(conflictKeys) == 0 {
+		// Fallback: no known unique index to target a conflict with, so
+		// fall back to the original select-then-insert-or-update path.
+		if exist, err := m.Filter(filter).Exist(); err != nil {
+			return false, 0, err
+		} else if exist {
+			if num, err := m.Filter(filter).Update(data); err != nil {
+				return false, 0, err
+			} else {
+				return false, num, nil
+			}
+		}
+
+		id, err := m.Insert(data)
+		if err != nil {
 			return false, 0, err
-		} else {
-			return false, num, nil
 		}
+		return true, id, nil
+	}
+
+	insertData := make(map[string]any, len(filter)+len(data))
+	for k, v := range filter {
+		insertData[k] = v
+	}
+	for k, v := range data {
+		insertData[k] = v
 	}
 
-	id, err := m.Insert(data)
+	id, created, rows, err := m.Upsert(conflictKeys, insertData, data)
 	if err != nil {
+		if errors.Is(err, sqlx.ErrNotFound) {
+			// data had no columns to update, so conflict rendered a DO
+			// NOTHING that skips the RETURNING row entirely for an
+			// existing row: nothing was created or changed.
+			return false, 0, nil
+		}
 		return false, 0, err
 	}
-	return true, id, nil
+	if created {
+		return true, id, nil
+	}
+	return false, rows, nil
 }
 
 func (m *Impl) GetC2CMap(column1, column2 string) (res map[any]any, err error) {
@@ -633,7 +1192,12 @@ func (m *Impl) GetC2CMap(column1, column2 string) (res map[any]any, err error) {
 		return nil, err
 	}
 
-	query := fmt.Sprintf("SELECT `%s`,`%s` FROM %s ", column1, column2, m.table)
+	distinct := ""
+	if m.qs.HasToManyJoin() {
+		distinct = "DISTINCT "
+	}
+	query := fmt.Sprintf("SELECT %s%s,%s FROM %s ", distinct, m.baseColumnRef(column1), m.baseColumnRef(column2), m.baseFromClause())
+	query += m.qs.GetJoinSQL()
 
 	filterSQL, filterArgs := m.qs.GetQuerySet()
 
@@ -656,18 +1220,423 @@ func (m *Impl) GetC2CMap(column1, column2 string) (res map[any]any, err error) {
 	return res, nil
 }
 
-func (m *Impl) CreateIfNotExist(data map[string]any) (id int64, created bool, err error) {
-	if exist, err := m.Filter(data).Exist(); err != nil {
-		return 0, false, err
-	} else if exist {
-		return 0, false, nil
+// preloadController builds an Impl bound to modelName's registered table and
+// relations but sharing m's connection/transaction state, so Preload's
+// follow-up queries run against the right related model on the same
+// session.
+func (m *Impl) preloadController(modelName string) (*Impl, error) {
+	reg, ok := lookupModel(modelName)
+	if !ok {
+		return nil, fmt.Errorf("zorm: Preload target model [%s] not registered; build its Controller with NewController/NewTxController first", modelName)
+	}
+
+	relationCfg := db.RelationConfig{
+		BaseTable: db.BaseAlias,
+		Relations: reg.relations,
+		Resolve:   resolveRelations,
+	}
+	return &Impl{
+		context:      m.ctx(),
+		conn:         m.conn,
+		sqlConn:      m.sqlConn,
+		model:        reg.model,
+		modelSlice:   reg.modelSlice,
+		table:        reg.tableName,
+		quotedTable:  reg.quotedTable,
+		fieldNameMap: reg.fieldNameMap,
+		fieldRows:    reg.fieldRows,
+		mTag:         reg.mTag,
+		dialect:      reg.dialect,
+		relationCfg:  relationCfg,
+		qs:           db.NewQuerySet(NewOperator(), reg.dialect, relationCfg),
+	}, nil
+}
+
+// fetchPreloadMaps runs the follow-up "WHERE matchColumn IN (values)" query
+// for rel.To in map mode, applying filter if one was registered via
+// PreloadWith.
+func (m *Impl) fetchPreloadMaps(rel db.Relation, matchColumn string, values []any, filter func(Controller) Controller) ([]map[string]any, error) {
+	ctl, err := m.preloadController(rel.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var target Controller = ctl.Filter(map[string]any{matchColumn + "__in": values})
+	if filter != nil {
+		target = filter(target)
+	}
+	return target.FindAll()
+}
+
+// fetchPreloadModels is fetchPreloadMaps for struct mode: it returns the
+// matching rows as a reflect.Value of type []*ChildModel, plus ChildModel's
+// reflect.Type for field lookups.
+func (m *Impl) fetchPreloadModels(rel db.Relation, matchColumn string, values []any, filter func(Controller) Controller) (reflect.Value, reflect.Type, error) {
+	ctl, err := m.preloadController(rel.To)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+
+	childPtrType := reflect.TypeOf(ctl.model)
+	slicePtr := reflect.New(reflect.SliceOf(childPtrType))
+
+	var target Controller = ctl.Filter(map[string]any{matchColumn + "__in": values})
+	if filter != nil {
+		target = filter(target)
+	}
+
+	if err = target.FindAllModel(slicePtr.Interface()); err != nil && !errors.Is(err, sqlx.ErrNotFound) {
+		return reflect.Value{}, nil, err
+	}
+
+	return slicePtr.Elem(), childPtrType.Elem(), nil
+}
+
+// runPreloadRows applies every registered Preload/PreloadWith path to rows
+// (as returned by FindOne/FindAll), attaching each relation's child row
+// (belongs-to) or child rows (has-many) under rows[i][path].
+func (m *Impl) runPreloadRows(rows []map[string]any) error {
+	for _, spec := range m.preloads {
+		rel, ok := m.relationCfg.Relations[spec.path]
+		if !ok {
+			logc.Errorf(m.ctx(), "Preload path [%s] is not a declared relation.", spec.path)
+			continue
+		}
+
+		var err error
+		if rel.Many {
+			err = m.attachHasManyRows(rows, spec, rel)
+		} else {
+			err = m.attachBelongsToRows(rows, spec, rel)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Impl) attachBelongsToRows(rows []map[string]any, spec preloadSpec, rel db.Relation) error {
+	values, seen := make([]any, 0, len(rows)), make(map[any]struct{}, len(rows))
+	for _, row := range rows {
+		v, ok := row[rel.FK]
+		if !ok || isZeroValue(v) {
+			continue
+		}
+		if _, dup := seen[v]; dup {
+			continue
+		}
+		seen[v] = struct{}{}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return nil
 	}
 
-	id, err = m.Insert(data)
+	children, err := m.fetchPreloadMaps(rel, primaryKeyColumn, values, spec.filter)
 	if err != nil {
+		return err
+	}
+
+	byID := make(map[any]map[string]any, len(children))
+	for _, child := range children {
+		byID[child[primaryKeyColumn]] = child
+	}
+
+	for _, row := range rows {
+		if child, ok := byID[row[rel.FK]]; ok {
+			row[spec.path] = child
+		}
+	}
+	return nil
+}
+
+func (m *Impl) attachHasManyRows(rows []map[string]any, spec preloadSpec, rel db.Relation) error {
+	values, seen := make([]any, 0, len(rows)), make(map[any]struct{}, len(rows))
+	for _, row := range rows {
+		v, ok := row[primaryKeyColumn]
+		if !ok || isZeroValue(v) {
+			continue
+		}
+		if _, dup := seen[v]; dup {
+			continue
+		}
+		seen[v] = struct{}{}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	children, err := m.fetchPreloadMaps(rel, rel.FK, values, spec.filter)
+	if err != nil {
+		return err
+	}
+
+	grouped := make(map[any][]map[string]any, len(children))
+	for _, child := range children {
+		grouped[child[rel.FK]] = append(grouped[child[rel.FK]], child)
+	}
+
+	for _, row := range rows {
+		row[spec.path] = grouped[row[primaryKeyColumn]]
+	}
+	return nil
+}
+
+// runPreloadOne is runPreloadModels for a single model, as returned by
+// FindOneModel.
+func (m *Impl) runPreloadOne(modelPtr any) error {
+	slicePtr := reflect.New(reflect.SliceOf(reflect.TypeOf(modelPtr)))
+	slicePtr.Elem().Set(reflect.Append(slicePtr.Elem(), reflect.ValueOf(modelPtr)))
+	return m.runPreloadModels(slicePtr.Interface())
+}
+
+// runPreloadModels applies every registered Preload/PreloadWith path to the
+// model slice pointed to by modelSlicePtr (as returned by FindAllModel),
+// setting each relation's struct field directly.
+func (m *Impl) runPreloadModels(modelSlicePtr any) error {
+	sliceVal := reflect.ValueOf(modelSlicePtr).Elem()
+	if sliceVal.Len() == 0 {
+		return nil
+	}
+	parentType := sliceVal.Type().Elem().Elem()
+
+	for _, spec := range m.preloads {
+		rel, ok := m.relationCfg.Relations[spec.path]
+		if !ok {
+			logc.Errorf(m.ctx(), "Preload path [%s] is not a declared relation.", spec.path)
+			continue
+		}
+
+		var err error
+		if rel.Many {
+			err = m.attachHasManyModels(sliceVal, parentType, spec, rel)
+		} else {
+			err = m.attachBelongsToModels(sliceVal, parentType, spec, rel)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Impl) attachBelongsToModels(sliceVal reflect.Value, parentType reflect.Type, spec preloadSpec, rel db.Relation) error {
+	fkField, ok := fieldByColumn(parentType, rel.FK)
+	if !ok {
+		return fmt.Errorf("zorm: Preload [%s]: fk column [%s] not found on %s", spec.path, rel.FK, parentType.Name())
+	}
+	relField, ok := parentType.FieldByName(rel.FieldName)
+	if !ok {
+		return fmt.Errorf("zorm: Preload [%s]: field [%s] not found on %s", spec.path, rel.FieldName, parentType.Name())
+	}
+
+	values, seen := make([]any, 0, sliceVal.Len()), make(map[any]struct{}, sliceVal.Len())
+	for i := 0; i < sliceVal.Len(); i++ {
+		v := sliceVal.Index(i).Elem().FieldByIndex(fkField.Index).Interface()
+		if isZeroValue(v) {
+			continue
+		}
+		if _, dup := seen[v]; dup {
+			continue
+		}
+		seen[v] = struct{}{}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	children, childType, err := m.fetchPreloadModels(rel, primaryKeyColumn, values, spec.filter)
+	if err != nil {
+		return err
+	}
+
+	idField, ok := fieldByColumn(childType, primaryKeyColumn)
+	if !ok {
+		return fmt.Errorf("zorm: Preload [%s]: primary key column [%s] not found on %s", spec.path, primaryKeyColumn, childType.Name())
+	}
+
+	byID := make(map[any]reflect.Value, children.Len())
+	for i := 0; i < children.Len(); i++ {
+		child := children.Index(i)
+		byID[child.Elem().FieldByIndex(idField.Index).Interface()] = child
+	}
+
+	for i := 0; i < sliceVal.Len(); i++ {
+		elem := sliceVal.Index(i).Elem()
+		if child, ok := byID[elem.FieldByIndex(fkField.Index).Interface()]; ok {
+			elem.FieldByIndex(relField.Index).Set(child)
+		}
+	}
+	return nil
+}
+
+func (m *Impl) attachHasManyModels(sliceVal reflect.Value, parentType reflect.Type, spec preloadSpec, rel db.Relation) error {
+	idField, ok := fieldByColumn(parentType, primaryKeyColumn)
+	if !ok {
+		return fmt.Errorf("zorm: Preload [%s]: primary key column [%s] not found on %s", spec.path, primaryKeyColumn, parentType.Name())
+	}
+	relField, ok := parentType.FieldByName(rel.FieldName)
+	if !ok {
+		return fmt.Errorf("zorm: Preload [%s]: field [%s] not found on %s", spec.path, rel.FieldName, parentType.Name())
+	}
+
+	values, seen := make([]any, 0, sliceVal.Len()), make(map[any]struct{}, sliceVal.Len())
+	for i := 0; i < sliceVal.Len(); i++ {
+		v := sliceVal.Index(i).Elem().FieldByIndex(idField.Index).Interface()
+		if isZeroValue(v) {
+			continue
+		}
+		if _, dup := seen[v]; dup {
+			continue
+		}
+		seen[v] = struct{}{}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	children, childType, err := m.fetchPreloadModels(rel, rel.FK, values, spec.filter)
+	if err != nil {
+		return err
+	}
+
+	fkField, ok := fieldByColumn(childType, rel.FK)
+	if !ok {
+		return fmt.Errorf("zorm: Preload [%s]: fk column [%s] not found on %s", spec.path, rel.FK, childType.Name())
+	}
+
+	grouped := make(map[any][]reflect.Value, children.Len())
+	for i := 0; i < children.Len(); i++ {
+		child := children.Index(i)
+		fk := child.Elem().FieldByIndex(fkField.Index).Interface()
+		grouped[fk] = append(grouped[fk], child)
+	}
+
+	for i := 0; i < sliceVal.Len(); i++ {
+		elem := sliceVal.Index(i).Elem()
+		group := grouped[elem.FieldByIndex(idField.Index).Interface()]
+		if len(group) == 0 {
+			continue
+		}
+		childSlice := reflect.MakeSlice(relField.Type, 0, len(group))
+		for _, child := range group {
+			childSlice = reflect.Append(childSlice, child)
+		}
+		elem.FieldByIndex(relField.Index).Set(childSlice)
+	}
+	return nil
+}
+
+func (m *Impl) CreateIfNotExist(conflictKeys []string, data map[string]any) (id int64, created bool, err error) {
+	if len(conflictKeys) == 0 {
+		// Fallback: no known unique index to target a conflict with, so
+		// fall back to the original select-then-insert path.
+		if exist, err := m.Filter(data).Exist(); err != nil {
+			return 0, false, err
+		} else if exist {
+			return 0, false, nil
+		}
+
+		id, err = m.Insert(data)
+		if err != nil {
+			return 0, false, err
+		}
+
+		return id, true, nil
+	}
+
+	var rows int64
+	id, created, rows, err = m.Upsert(conflictKeys, data, nil)
+	if err != nil {
+		if errors.Is(err, sqlx.ErrNotFound) {
+			// ON CONFLICT DO NOTHING skips the RETURNING row entirely for
+			// an existing row; look it up to report its id.
+			existing, ferr := m.Filter(data).FindOne()
+			if ferr != nil {
+				return 0, false, ferr
+			}
+			existingID, _ := existing[primaryKeyColumn].(int64)
+			return existingID, false, nil
+		}
 		return 0, false, err
 	}
+	if !created && rows == 0 {
+		// MySQL's no-op "ON DUPLICATE KEY UPDATE pk=pk" and SQLite's DO
+		// NOTHING (run without RETURNING) succeed without error and without
+		// a LastInsertId for an already-existing row; look it up the same
+		// way the Postgres ErrNotFound branch above does, so id still
+		// identifies the row.
+		existing, ferr := m.Filter(data).FindOne()
+		if ferr != nil {
+			return 0, false, ferr
+		}
+		existingID, _ := existing[primaryKeyColumn].(int64)
+		return existingID, false, nil
+	}
+
+	return id, created, nil
+}
+
+// Transaction runs fn against a Controller bound to a single transaction.
+// Calling Transaction again from within fn (on the Controller it receives,
+// or on another Controller built from the same ctx via NewTxController)
+// nests via an auto-named SAVEPOINT instead of opening a new transaction.
+func (m *Impl) Transaction(ctx context.Context, fn func(txCtl Controller) error) (err error) {
+	if ctx == nil {
+		ctx = m.ctx()
+	}
+
+	state, ok := ctx.Value(txStateKey{}).(*txState)
+	if !ok {
+		// fn only receives txCtl, so a nested call usually passes the outer
+		// ctx; fall back to the transaction state txCtl itself carries.
+		if state, ok = m.ctx().Value(txStateKey{}).(*txState); ok {
+			ctx = context.WithValue(ctx, txStateKey{}, state)
+		}
+	}
+	if ok {
+		state.depth++
+		savepoint := fmt.Sprintf("zorm_sp_%d", state.depth)
+
+		if _, err = state.session.ExecCtx(ctx, "SAVEPOINT "+savepoint); err != nil {
+			logc.Errorf(ctx, "Transaction savepoint error: %+v", err)
+			return err
+		}
+
+		if err = fn(m.withSession(ctx, state.session)); err != nil {
+			if _, rbErr := state.session.ExecCtx(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rbErr != nil {
+				logc.Errorf(ctx, "Transaction rollback to savepoint error: %+v", rbErr)
+			}
+			return err
+		}
+
+		if _, err = state.session.ExecCtx(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+			logc.Errorf(ctx, "Transaction release savepoint error: %+v", err)
+		}
+		return err
+	}
 
-	return id, true, nil
+	if m.sqlConn == nil {
+		return errors.New("zorm: Transaction requires a Controller built from NewController")
+	}
+
+	return m.sqlConn.TransactCtx(ctx, func(txCtx context.Context, session sqlx.Session) error {
+		txCtx = context.WithValue(txCtx, txStateKey{}, &txState{session: session})
+		return fn(m.withSession(txCtx, session))
+	})
 }
 
+// withSession returns a copy of m bound to session and ctx, with a fresh
+// query state, for use as the Controller handed to a Transaction callback.
+func (m *Impl) withSession(ctx context.Context, session sqlx.Session) *Impl {
+	clone := *m
+	clone.context = ctx
+	clone.conn = session
+	clone.sqlConn = nil
+	clone.qs = db.NewQuerySet(NewOperator(), m.dialect, m.relationCfg)
+	return &clone
+}
@@ -16,6 +16,8 @@ var operators = map[string]string{
 	"iendswith":   "LIKE",
 	"in":          "IN",
 	"between":     "BETWEEN",
+	"regex":       "REGEXP BINARY",
+	"iregex":      "REGEXP",
 }
 
 type operator struct{}
@@ -27,4 +29,3 @@ func NewOperator() *operator {
 func (d *operator) OperatorSQL(operator string) string {
 	return operators[operator]
 }
- 
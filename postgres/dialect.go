@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+)
+
+type dialect struct{}
+
+func NewDialect() *dialect {
+	return &dialect{}
+}
+
+func (d *dialect) QuoteIdentifier(name string) string {
+	return `"` + name + `"`
+}
+
+func (d *dialect) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+func (d *dialect) LimitOffset(limit, offset int64) string {
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+}
+
+func (d *dialect) IsDuplicateKey(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "23505")
+}
+
+func (d *dialect) InsertReturning(column string) string {
+	return " RETURNING " + column
+}
+
+func (d *dialect) UpsertClause(conflictKeys, updateColumns []string) string {
+	keys := make([]string, len(conflictKeys))
+	for i, k := range conflictKeys {
+		keys[i] = d.QuoteIdentifier(k)
+	}
+
+	if len(updateColumns) == 0 {
+		return fmt.Sprintf(" ON CONFLICT (%s) DO NOTHING", strings.Join(keys, ","))
+	}
+
+	sets := make([]string, len(updateColumns))
+	for i, c := range updateColumns {
+		q := d.QuoteIdentifier(c)
+		sets[i] = fmt.Sprintf("%s=EXCLUDED.%s", q, q)
+	}
+	return fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(keys, ","), strings.Join(sets, ","))
+}
+
+func (d *dialect) UpsertReturning(idColumn string) string {
+	// xmax = 0 holds for the tuple just inserted by this command; a
+	// conflicting row that went through DO UPDATE carries a non-zero xmax.
+	return fmt.Sprintf(" RETURNING %s AS id, (xmax = 0) AS zorm_created", d.QuoteIdentifier(idColumn))
+}
+
+// datePartFields maps a "year"/"month"/"day"/"week_day" lookup part to the
+// Postgres EXTRACT field that returns it. week_day uses DOW (0=Sunday..
+// 6=Saturday), not Django's numbering.
+var datePartFields = map[string]string{
+	"year": "YEAR", "month": "MONTH", "day": "DAY", "week_day": "DOW",
+}
+
+func (d *dialect) DatePartExpr(part, columnRef string) string {
+	return fmt.Sprintf("EXTRACT(%s FROM %s)", datePartFields[part], columnRef)
+}
+
+func (d *dialect) SupportsUpdateJoin() bool {
+	// Postgres has no UPDATE ... JOIN syntax; its equivalent, UPDATE ...
+	// FROM, is a different statement shape that Controller.Update does
+	// not render, so a joined query set is rejected instead.
+	return false
+}
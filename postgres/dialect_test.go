@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDialect_Placeholder(t *testing.T) {
+	d := NewDialect()
+	if got := d.Placeholder(1); got != "$1" {
+		t.Fatalf("Placeholder(1) = %q, want %q", got, "$1")
+	}
+	if got := d.Placeholder(12); got != "$12" {
+		t.Fatalf("Placeholder(12) = %q, want %q", got, "$12")
+	}
+}
+
+func TestDialect_UpsertClause(t *testing.T) {
+	d := NewDialect()
+
+	cases := []struct {
+		name          string
+		conflictKeys  []string
+		updateColumns []string
+		want          string
+	}{
+		{
+			name:         "no update columns does nothing",
+			conflictKeys: []string{"id"},
+			want:         ` ON CONFLICT ("id") DO NOTHING`,
+		},
+		{
+			name:          "update columns excluded self-reference",
+			conflictKeys:  []string{"id"},
+			updateColumns: []string{"name"},
+			want:          ` ON CONFLICT ("id") DO UPDATE SET "name"=EXCLUDED."name"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := d.UpsertClause(c.conflictKeys, c.updateColumns)
+			if got != c.want {
+				t.Fatalf("UpsertClause() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestDialect_UpsertReturning(t *testing.T) {
+	d := NewDialect()
+	got := d.UpsertReturning("id")
+	const want = ` RETURNING "id" AS id, (xmax = 0) AS zorm_created`
+	if got != want {
+		t.Fatalf("UpsertReturning(\"id\") = %q, want %q", got, want)
+	}
+}
+
+func TestDialect_DatePartExpr(t *testing.T) {
+	d := NewDialect()
+
+	cases := []struct {
+		part string
+		want string
+	}{
+		{"year", `EXTRACT(YEAR FROM "created_at")`},
+		{"week_day", `EXTRACT(DOW FROM "created_at")`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.part, func(t *testing.T) {
+			got := d.DatePartExpr(c.part, `"created_at"`)
+			if got != c.want {
+				t.Fatalf("DatePartExpr(%q) = %q, want %q", c.part, got, c.want)
+			}
+		})
+	}
+}
+
+func TestDialect_SupportsUpdateJoin(t *testing.T) {
+	d := NewDialect()
+	if d.SupportsUpdateJoin() {
+		t.Fatal("SupportsUpdateJoin() = true, want false")
+	}
+}
+
+func TestDialect_IsDuplicateKey(t *testing.T) {
+	d := NewDialect()
+	if d.IsDuplicateKey(nil) {
+		t.Fatal("IsDuplicateKey(nil) = true, want false")
+	}
+	if !d.IsDuplicateKey(errors.New("pq: duplicate key value violates unique constraint (SQLSTATE 23505)")) {
+		t.Fatal("IsDuplicateKey(23505 error) = false, want true")
+	}
+	if d.IsDuplicateKey(errors.New("some other error")) {
+		t.Fatal("IsDuplicateKey(unrelated error) = true, want false")
+	}
+}
+
+func TestDialect_InsertReturning(t *testing.T) {
+	d := NewDialect()
+	if got := d.InsertReturning("id"); !strings.Contains(got, "id") {
+		t.Fatalf("InsertReturning(\"id\") = %q, want it to contain %q", got, "id")
+	}
+}
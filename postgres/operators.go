@@ -0,0 +1,31 @@
+package postgres
+
+var operators = map[string]string{
+	"exact":       "=",
+	"exclude":     "!=",
+	"iexact":      "ILIKE",
+	"contains":    "LIKE",
+	"icontains":   "ILIKE",
+	"gt":          ">",
+	"gte":         ">=",
+	"lt":          "<",
+	"lte":         "<=",
+	"startswith":  "LIKE",
+	"endswith":    "LIKE",
+	"istartswith": "ILIKE",
+	"iendswith":   "ILIKE",
+	"in":          "IN",
+	"between":     "BETWEEN",
+	"regex":       "~",
+	"iregex":      "~*",
+}
+
+type operator struct{}
+
+func NewOperator() *operator {
+	return &operator{}
+}
+
+func (d *operator) OperatorSQL(operator string) string {
+	return operators[operator]
+}
@@ -23,9 +23,128 @@ type Operator interface {
 	OperatorSQL(operator string) string
 }
 
+// Dialect owns every piece of SQL rendering that differs between database
+// engines: identifier quoting, parameter placeholders, pagination syntax and
+// duplicate-key classification. Built-in dialects live in the mysql,
+// postgres and sqlite packages.
+type Dialect interface {
+	// QuoteIdentifier quotes a bare column or table name, e.g. `name` for
+	// MySQL or "name" for Postgres/SQLite.
+	QuoteIdentifier(name string) string
+	// Placeholder renders the n-th (1-based) bind parameter, e.g. "?" for
+	// MySQL/SQLite or "$n" for Postgres.
+	Placeholder(n int) string
+	// LimitOffset renders the pagination clause for the given limit/offset.
+	LimitOffset(limit, offset int64) string
+	// IsDuplicateKey reports whether err is a unique/duplicate-key
+	// violation as raised by this dialect's driver.
+	IsDuplicateKey(err error) bool
+	// InsertReturning renders the clause (if any) that must be appended to
+	// an INSERT statement to recover the generated value of column, e.g.
+	// "" for MySQL/SQLite (use LastInsertId) or " RETURNING id" for
+	// Postgres.
+	InsertReturning(column string) string
+	// UpsertClause renders the ON DUPLICATE KEY UPDATE / ON CONFLICT DO
+	// UPDATE tail appended to an INSERT statement, referencing the
+	// just-inserted values so no extra bind arguments are needed (MySQL's
+	// VALUES(col), Postgres/SQLite's EXCLUDED.col). An empty updateColumns
+	// renders a no-op/DO NOTHING upsert instead.
+	UpsertClause(conflictKeys, updateColumns []string) string
+	// UpsertReturning renders the trailing clause (if any) needed to
+	// recover both the row's idColumn and whether it was freshly inserted;
+	// "" tells the caller to fall back to LastInsertId/RowsAffected.
+	UpsertReturning(idColumn string) string
+	// DatePartExpr renders the expression that extracts part (one of
+	// "year", "month", "day" or "week_day") out of the already-quoted
+	// column reference columnRef, e.g. MySQL's "YEAR(col)", Postgres'
+	// "EXTRACT(YEAR FROM col)" or SQLite's "CAST(strftime('%Y', col) AS
+	// INTEGER)". Used to render "date__year=2024"-style lookups.
+	DatePartExpr(part, columnRef string) string
+	// SupportsUpdateJoin reports whether this dialect accepts a JOIN
+	// between the table name and SET in an UPDATE statement (true for
+	// MySQL's "UPDATE t JOIN ... SET ..."). Postgres/SQLite have no such
+	// syntax (Postgres' equivalent is an UPDATE ... FROM, a different
+	// statement shape), so Controller.Update rejects a joined query set
+	// on those dialects rather than rendering invalid SQL.
+	SupportsUpdateJoin() bool
+}
+
+// datePartLookups is the set of fieldLookups suffixes filterHandler renders
+// via Dialect.DatePartExpr instead of comparing columnRef directly.
+var datePartLookups = map[string]bool{
+	"year": true, "month": true, "day": true, "week_day": true,
+}
+
+// Relation describes one foreign-key hop declared via a zorm:"fk=Col,to=Model"
+// struct tag: FK is the foreign-key column (on the declaring model for
+// belongs-to, on the related model for has-many), To is the
+// related model's name (used to look up its own relations for the next hop
+// of a "__"-separated path) and Table is that model's table name. FieldName
+// and Many describe the Go struct field the tag was read from (a single
+// pointer for belongs-to, a slice of pointers for has-many), which Preload
+// uses to stitch eager-loaded rows back onto it.
+type Relation struct {
+	FK        string
+	To        string
+	Table     string
+	FieldName string
+	Many      bool
+	// JoinType is "INNER" or "LEFT" (default "LEFT" when unset, since a
+	// belongs-to FK may be nullable and a has-many side may have no rows).
+	JoinType string
+}
+
+// RelationMap resolves a model's tag-declared relations by the lowercased
+// name of the struct field that carries the zorm tag, e.g. "user".
+type RelationMap map[string]Relation
+
+// RelationResolver looks up the relations declared on another model by name,
+// so a multi-hop path like "user__profile__name" can resolve its second hop
+// (profile, declared on the User model) without the QuerySet knowing about
+// every model up front.
+type RelationResolver func(modelName string) RelationMap
+
+// RelationConfig supplies everything a QuerySetImpl needs to turn
+// "__"-separated relation paths into JOINs: the reference the first hop's
+// JOIN condition qualifies the base table's columns with (BaseAlias, since
+// the base table is aliased to it whenever a join is present), the base
+// model's own relations, and a resolver for relations
+// declared on related models further down the path.
+type RelationConfig struct {
+	BaseTable string
+	Relations RelationMap
+	Resolve   RelationResolver
+}
+
+// relationPK is the column every related model is assumed to expose as its
+// primary key, mirroring norm.primaryKeyColumn.
+const relationPK = "id"
+
+// BaseAlias is the table alias the base table is given (mirroring Beego's
+// T0) whenever a relation is joined into the query, so base-table columns
+// can be qualified and stay unambiguous alongside the joined tables' own
+// T1, T2, ... aliases (e.g. both commonly having an "id" column).
+const BaseAlias = "T0"
+
+// baseFieldMarker is embedded by resolveFieldRef in place of a base-table
+// field's alias, since at render time it isn't yet known whether the query
+// will end up joining any relation; qualifyBaseFields resolves it to
+// BaseAlias+"." once all joins are registered, or strips it if none were.
+const baseFieldMarker = "\x00ZORM_BASE\x00"
+
+// joinInfo records one join that has already been added to a query, keyed by
+// its full "__"-joined relation path, so the same path is never joined twice.
+type joinInfo struct {
+	alias string
+	sql   string
+	many  bool
+}
+
 type QuerySet[T Filter] interface {
 	GetQuerySet() (string, []any)
-	FilterToSQL(filter map[string]any) QuerySet
+	// FilterToSQL accepts one or more map[string]any clauses or *QNode
+	// trees built with Q(...), ANDing them together.
+	FilterToSQL(filter ...any) QuerySet
 	ExcludeToSQL(exclude map[string]any) QuerySet
 	GetOrderBySQL() string
 	OrderByToSQL(orderBy []string) QuerySet
@@ -35,6 +154,19 @@ type QuerySet[T Filter] interface {
 	GetSelectSQL() string
 	GroupByToSQL(groupBy []string) QuerySet
 	GetGroupBySQL() string
+	// SetParamOffset tells the query set how many placeholders have
+	// already been consumed by SQL built outside of it (e.g. an INSERT
+	// column list or an UPDATE SET clause), so dialects with positional
+	// placeholders (Postgres' $n) keep numbering correctly.
+	SetParamOffset(n int) QuerySet
+	// GetJoinSQL returns the accumulated JOIN clauses registered while
+	// resolving "__"-separated relation paths in Filter/Exclude/Select/
+	// OrderBy, in the order they were first referenced.
+	GetJoinSQL() string
+	// HasToManyJoin reports whether any relation joined into this query is
+	// has-many, so the base row set may contain duplicate base rows (one
+	// per matching child) that callers need to de-duplicate.
+	HasToManyJoin() bool
 }
 
 type queryFilter struct {
@@ -44,6 +176,11 @@ type queryFilter struct {
 
 type QuerySetImpl struct {
 	Operator
+	dialect          Dialect
+	paramOffset      int
+	relationCfg      RelationConfig
+	joins            map[string]*joinInfo
+	joinOrder        []string
 	selectColumn     string
 	whereCondition   queryFilter
 	filterCondition  []queryFilter
@@ -55,9 +192,12 @@ type QuerySetImpl struct {
 
 var _ QuerySet = (*QuerySetImpl)(nil)
 
-func NewQuerySet(op Operator) QuerySet {
+func NewQuerySet(op Operator, dialect Dialect, relations RelationConfig) QuerySet {
 	return &QuerySetImpl{
 		Operator:         op,
+		dialect:          dialect,
+		relationCfg:      relations,
+		joins:            make(map[string]*joinInfo),
 		selectColumn:     "",
 		whereCondition:   queryFilter{},
 		filterCondition:  make([]queryFilter, 0, 10),
@@ -67,6 +207,135 @@ func NewQuerySet(op Operator) QuerySet {
 	}
 }
 
+// resolveFieldRef walks the leading "__"-separated relation hops of fl,
+// registering a JOIN for each one not already present, and returns the
+// dialect-quoted (and, if joined, alias-qualified) reference for the first
+// segment that isn't a known relation, along with how many segments were
+// consumed as hops.
+func (p *QuerySetImpl) resolveFieldRef(fl []string) (ref string, consumed int) {
+	relations := p.relationCfg.Relations
+	alias := ""
+	path := ""
+
+	for consumed < len(fl)-1 {
+		rel, ok := relations[fl[consumed]]
+		if !ok {
+			break
+		}
+
+		if path == "" {
+			path = fl[consumed]
+		} else {
+			path += "__" + fl[consumed]
+		}
+		alias = p.registerJoin(path, alias, rel)
+
+		if p.relationCfg.Resolve != nil {
+			relations = p.relationCfg.Resolve(rel.To)
+		} else {
+			relations = nil
+		}
+		consumed++
+	}
+
+	field := fl[consumed]
+	if alias == "" {
+		return baseFieldMarker + p.dialect.QuoteIdentifier(field), consumed
+	}
+	return alias + "." + p.dialect.QuoteIdentifier(field), consumed
+}
+
+// qualifyBaseFields resolves the baseFieldMarker left by resolveFieldRef for
+// every bare base-table field referenced in sql: once all Filter/Exclude/
+// Select/OrderBy calls have run, the query set knows whether any relation
+// was actually joined, so this is done once at final SQL assembly rather
+// than at each field's render time.
+func (p *QuerySetImpl) qualifyBaseFields(sql string) string {
+	prefix := ""
+	if len(p.joinOrder) > 0 {
+		prefix = BaseAlias + "."
+	}
+	return strings.ReplaceAll(sql, baseFieldMarker, prefix)
+}
+
+// registerJoin records the JOIN for path (a "__"-joined relation chain) the
+// first time it is referenced and returns its table alias; later references
+// to the same path reuse that alias instead of joining again.
+func (p *QuerySetImpl) registerJoin(path, fromAlias string, rel Relation) string {
+	if info, ok := p.joins[path]; ok {
+		return info.alias
+	}
+
+	fromRef := fromAlias
+	if fromRef == "" {
+		fromRef = p.relationCfg.BaseTable
+	}
+
+	joinType := rel.JoinType
+	if joinType == "" {
+		joinType = "LEFT"
+	}
+
+	alias := fmt.Sprintf("T%d", len(p.joinOrder)+1)
+	toCol, fromCol := relationPK, rel.FK
+	if rel.Many {
+		// has-many: the FK column lives on the related table.
+		toCol, fromCol = rel.FK, relationPK
+	}
+	sql := fmt.Sprintf(" %s JOIN %s AS %s ON %s.%s = %s.%s",
+		joinType, p.dialect.QuoteIdentifier(rel.Table), alias,
+		alias, p.dialect.QuoteIdentifier(toCol),
+		fromRef, p.dialect.QuoteIdentifier(fromCol))
+
+	p.joins[path] = &joinInfo{alias: alias, sql: sql, many: rel.Many}
+	p.joinOrder = append(p.joinOrder, path)
+	return alias
+}
+
+func (p *QuerySetImpl) GetJoinSQL() string {
+	sql := ""
+	for _, path := range p.joinOrder {
+		sql += p.joins[path].sql
+	}
+	return sql
+}
+
+func (p *QuerySetImpl) HasToManyJoin() bool {
+	for _, path := range p.joinOrder {
+		if p.joins[path].many {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *QuerySetImpl) SetParamOffset(n int) QuerySet {
+	p.paramOffset = n
+	return p
+}
+
+// ph renders a neutral bind-parameter marker. Filter/Exclude clauses are
+// built independently and in call order, long before GetQuerySet knows
+// where in the final WHERE clause (and thus which dialect placeholder
+// number) each one will land, so rendering defers the actual numbering to
+// GetQuerySet's final assembly instead of numbering here.
+func (p *QuerySetImpl) ph() string {
+	return "?"
+}
+
+// renumberPlaceholders replaces each neutral "?" marker in sql, in order,
+// with this dialect's positional placeholder starting after paramOffset
+// (the count SetParamOffset reserved for SQL built outside the QuerySet,
+// e.g. an UPDATE's SET clause), so args lines up with the markers'
+// left-to-right order in the final SQL regardless of the Filter/Exclude
+// call order that produced each clause.
+func (p *QuerySetImpl) renumberPlaceholders(sql string, n int) string {
+	for i := 1; i <= n; i++ {
+		sql = strings.Replace(sql, "?", p.dialect.Placeholder(p.paramOffset+i), 1)
+	}
+	return sql
+}
+
 func (p *QuerySetImpl) GetQuerySet() (sql string, args []any) {
 	if p.whereCondition.SQL != "" {
 		return " WHERE " + p.whereCondition.SQL, p.whereCondition.Args
@@ -92,6 +361,9 @@ func (p *QuerySetImpl) GetQuerySet() (sql string, args []any) {
 		}
 	}
 
+	sql = p.renumberPlaceholders(sql, len(args))
+	sql = p.qualifyBaseFields(sql)
+
 	return " WHERE " + strings.TrimSpace(sql[:len(sql)-4]), args
 }
 
@@ -101,16 +373,22 @@ func (p *QuerySetImpl) WhereToSQL(cond string, args ...any) QuerySet {
 	return p
 }
 
-func (p *QuerySetImpl) filterHandler(filter ...T) (filterSql string, filterArgs []any) {
+// renderMapClause renders one map[string]any clause — the flat shape Filter
+// has always accepted — honoring a key's "__Q" suffix to OR it into the
+// clause built so far instead of AND-ing it in, e.g.
+// {"a": 1, "b__Q": 2} renders "a=? OR b=?" when "a" is visited first. Keys
+// are visited in Go's random map iteration order, so the same map can also
+// render "b=? AND a=?". filterHandler calls this for
+// every map[string]any passed to Filter/FilterToSQL, and QNode.render calls
+// it for every leaf of a Q(...) tree.
+func (p *QuerySetImpl) renderMapClause(filter map[string]any) (filterSql string, filterArgs []any) {
 	if len(filter) == 0 {
 		return
 	}
 
 	var (
-		baseSQL   = " `%s`%s? "
-		fieldName string
-		operator  string
-		flag      = 0
+		operator string
+		flag     = 0
 	)
 
 	filterSql = ""
@@ -118,25 +396,49 @@ func (p *QuerySetImpl) filterHandler(filter ...T) (filterSql string, filterArgs
 
 	for fieldLookups, filedValue := range filter {
 		fl := strings.Split(fieldLookups, "__")
-		fieldName = fl[0]
-		if len(fl) == 1 {
+		qname, consumed := p.resolveFieldRef(fl)
+		rest := fl[consumed+1:]
+
+		switch len(rest) {
+		case 0:
 			operator = "exact"
 			flag = 0
-		} else if len(fl) == 2 {
-			if fl[1] != "Q" {
-				operator = fl[1]
+		case 1:
+			if rest[0] != "Q" {
+				operator = rest[0]
 				flag = 0
 			} else {
 				operator = "exact"
 				flag = 1
 			}
-		} else if len(fl) == 3 {
-			if fl[2] == "Q" {
-				operator = fl[1]
+		case 2:
+			if rest[1] == "Q" {
+				operator = rest[0]
 				flag = 1
 			} else {
 				log.Panicf("FieldLookups [%s] is invalid.", fieldLookups)
 			}
+		default:
+			log.Panicf("FieldLookups [%s] is invalid.", fieldLookups)
+		}
+
+		if operator == "isnull" {
+			b, ok := filedValue.(bool)
+			if !ok {
+				log.Panicf("Operator [isnull] requires a bool value for key [%s].", fieldLookups)
+			}
+			clause := "IS NOT NULL"
+			if b {
+				clause = "IS NULL"
+			}
+			filterSql += ANDOR[flag]
+			filterSql += fmt.Sprintf(" %s %s ", qname, clause)
+			continue
+		}
+
+		if datePartLookups[operator] {
+			qname = p.dialect.DatePartExpr(operator, qname)
+			operator = "exact"
 		}
 
 		op := p.OperatorSQL(operator)
@@ -153,7 +455,7 @@ func (p *QuerySetImpl) filterHandler(filter ...T) (filterSql string, filterArgs
 			}
 
 			filterSql += ANDOR[flag]
-			filterSql += fmt.Sprintf(baseSQL, fieldName, op)
+			filterSql += fmt.Sprintf(" %s%s%s ", qname, op, p.ph())
 			filterArgs = append(filterArgs, filedValue)
 		case reflect.Slice, reflect.Array:
 			if v.Len() == 0 {
@@ -171,11 +473,19 @@ func (p *QuerySetImpl) filterHandler(filter ...T) (filterSql string, filterArgs
 
 			switch operator {
 			case "exact", "exclude", "contains", "icontains":
-				filterSql += fmt.Sprintf(" ( %s %s ?", fieldName, op) + strings.Repeat(fmt.Sprintf(" %s %s %s ?", ANDOR[flag], fieldName, op), v.Len()-1) + " ) "
+				parts := make([]string, v.Len())
+				for i := range parts {
+					parts[i] = fmt.Sprintf("%s %s %s", qname, op, p.ph())
+				}
+				filterSql += " ( " + strings.Join(parts, " "+ANDOR[flag]+" ") + " ) "
 			case "in":
-				filterSql += fmt.Sprintf(" %s %s %s (?"+strings.Repeat(",?", v.Len()-1)+") ", fieldName, BLANKNOT[flag], op)
+				phs := make([]string, v.Len())
+				for i := range phs {
+					phs[i] = p.ph()
+				}
+				filterSql += fmt.Sprintf(" %s %s %s (%s) ", qname, BLANKNOT[flag], op, strings.Join(phs, ","))
 			case "between":
-				filterSql += fmt.Sprintf(" %s %s %s ? AND ? ", fieldName, BLANKNOT[flag], op)
+				filterSql += fmt.Sprintf(" %s %s %s %s AND %s ", qname, BLANKNOT[flag], op, p.ph(), p.ph())
 			default:
 				log.Panicf("Unsupported slice operator [%s].", operator)
 			}
@@ -203,8 +513,101 @@ func (p *QuerySetImpl) filterHandler(filter ...T) (filterSql string, filterArgs
 	return filterSql, filterArgs
 }
 
-func (p *QuerySetImpl) FilterToSQL(filter ...map[string]any) QuerySet {
-	filterSQL, filterArgs := p.filterHandler(filter)
+// filterHandler renders every entry passed to Filter/FilterToSQL — each a
+// map[string]any or a *QNode built with Q(...) — ANDing them together.
+func (p *QuerySetImpl) filterHandler(filter ...any) (filterSql string, filterArgs []any) {
+	if len(filter) == 0 {
+		return
+	}
+
+	var parts []string
+	filterArgs = []any{}
+
+	for _, f := range filter {
+		var (
+			sql  string
+			args []any
+		)
+		switch v := f.(type) {
+		case map[string]any:
+			sql, args = p.renderMapClause(v)
+		case *QNode:
+			sql, args = v.render(p.renderMapClause)
+		default:
+			log.Panicf("Unsupported filter type [%T]; use map[string]any or a zorm.Q(...) tree.", f)
+		}
+		if sql == "" {
+			continue
+		}
+		parts = append(parts, sql)
+		filterArgs = append(filterArgs, args...)
+	}
+
+	return strings.Join(parts, " AND "), filterArgs
+}
+
+// QNode is a composable boolean condition tree built with Q, letting
+// callers nest AND/OR/NOT arbitrarily instead of the single flat map
+// Filter/FilterToSQL otherwise accepts, e.g.
+// Q(map[string]any{"a": 1}).And(Q(map[string]any{"b": 2}).Or(Q(map[string]any{"c__in": ids}).Not()))
+type QNode struct {
+	clause      map[string]any
+	left, right *QNode
+	connector   string
+}
+
+// Q builds a leaf QNode from a field-lookup clause, the same shape Filter
+// and FilterToSQL accept directly.
+func Q(clause map[string]any) *QNode {
+	return &QNode{clause: clause}
+}
+
+// And combines q and other with AND.
+func (q *QNode) And(other *QNode) *QNode {
+	return &QNode{left: q, right: other, connector: "AND"}
+}
+
+// Or combines q and other with OR.
+func (q *QNode) Or(other *QNode) *QNode {
+	return &QNode{left: q, right: other, connector: "OR"}
+}
+
+// Not negates q, rendering "NOT (...)" around it.
+func (q *QNode) Not() *QNode {
+	return &QNode{left: q, connector: "NOT"}
+}
+
+// render turns q into a parenthesized SQL boolean expression and its bind
+// args, using renderLeaf (QuerySetImpl.renderMapClause) for every leaf.
+func (q *QNode) render(renderLeaf func(map[string]any) (string, []any)) (string, []any) {
+	if q == nil {
+		return "", nil
+	}
+
+	switch q.connector {
+	case "":
+		return renderLeaf(q.clause)
+	case "NOT":
+		sql, args := q.left.render(renderLeaf)
+		if sql == "" {
+			return "", nil
+		}
+		return "NOT (" + sql + ")", args
+	default:
+		lsql, largs := q.left.render(renderLeaf)
+		rsql, rargs := q.right.render(renderLeaf)
+		switch {
+		case lsql == "":
+			return rsql, rargs
+		case rsql == "":
+			return lsql, largs
+		}
+		return fmt.Sprintf("(%s) %s (%s)", lsql, q.connector, rsql), append(largs, rargs...)
+	}
+}
+
+func (p *QuerySetImpl) FilterToSQL(filter ...any) QuerySet {
+	filterSQL, filterArgs := p.filterHandler(filter...)
 	if filterSQL == "" {
 		return p
 	}
@@ -230,7 +633,7 @@ func (p *QuerySetImpl) ExcludeToSQL(exclude map[string]any) QuerySet {
 
 func (p *QuerySetImpl) GetOrderBySQL() string {
 	if strings.HasPrefix(p.orderBySQL, ",") {
-		return " ORDER BY" + p.orderBySQL[1:]
+		return " ORDER BY" + p.qualifyBaseFields(p.orderBySQL[1:])
 	}
 	return ""
 }
@@ -240,19 +643,20 @@ func (p *QuerySetImpl) OrderByToSQL(orderBy []string) QuerySet {
 		return p
 	}
 
-	asc := true
 	for _, by := range orderBy {
 		p.orderBySQL += ","
 		by = strings.TrimSpace(by)
+		asc := true
 		if strings.HasPrefix(by, "-") {
 			by = by[1:]
 			asc = false
 		}
 
+		ref, _ := p.resolveFieldRef(strings.Split(by, "__"))
 		if asc {
-			p.orderBySQL += fmt.Sprintf(" `%s` ASC", by)
+			p.orderBySQL += fmt.Sprintf(" %s ASC", ref)
 		} else {
-			p.orderBySQL += fmt.Sprintf(" `%s` DESC", by)
+			p.orderBySQL += fmt.Sprintf(" %s DESC", ref)
 		}
 	}
 	if strings.HasSuffix(p.orderBySQL, ",") {
@@ -268,17 +672,23 @@ func (p *QuerySetImpl) GetLimitSQL() string {
 
 func (p *QuerySetImpl) LimitToSQL(pageSize, pageNum int64) QuerySet {
 	if pageSize > 0 && pageNum > 0 {
-		var offset, limit int64
-		offset = (pageNum - 1) * pageSize
-		limit = pageSize
-		p.limitSQL = fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+		offset := (pageNum - 1) * pageSize
+		p.limitSQL = p.dialect.LimitOffset(pageSize, offset)
 	}
 
 	return p
 }
 
 func (p *QuerySetImpl) SelectToSQL(columns []string) QuerySet {
-	p.selectColumn = strings.Join(columns, ",")
+	resolved := make([]string, len(columns))
+	for i, col := range columns {
+		if strings.Contains(col, "__") {
+			resolved[i], _ = p.resolveFieldRef(strings.Split(col, "__"))
+		} else {
+			resolved[i] = col
+		}
+	}
+	p.selectColumn = strings.Join(resolved, ",")
 	return p
 }
 
@@ -286,11 +696,15 @@ func (p *QuerySetImpl) GetSelectSQL() string {
 	if p.selectColumn == "" {
 		return "*"
 	}
-	return p.selectColumn
+	return p.qualifyBaseFields(p.selectColumn)
 }
 
 func (p *QuerySetImpl) GroupByToSQL(groupBy []string) QuerySet {
-	p.groupSQL = "`" + strings.Join(groupBy, "`,`") + "`"
+	quoted := make([]string, len(groupBy))
+	for i, by := range groupBy {
+		quoted[i] = p.dialect.QuoteIdentifier(by)
+	}
+	p.groupSQL = strings.Join(quoted, ",")
 	return p
 }
 
@@ -300,4 +714,3 @@ func (p *QuerySetImpl) GetGroupBySQL() string {
 	}
 	return ""
 }
-
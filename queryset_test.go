@@ -0,0 +1,187 @@
+package zorm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeDialect is a minimal Dialect test double. positional selects between
+// MySQL/SQLite-style positional "?" (n ignored) and Postgres-style numbered
+// "$n", the two placeholder schemes chunk0-1's renumbering bug depends on.
+type fakeDialect struct {
+	positional bool
+}
+
+func (d *fakeDialect) QuoteIdentifier(name string) string { return "`" + name + "`" }
+
+func (d *fakeDialect) Placeholder(n int) string {
+	if d.positional {
+		return "?"
+	}
+	return fmt.Sprintf("$%d", n)
+}
+
+func (d *fakeDialect) LimitOffset(limit, offset int64) string                  { return "" }
+func (d *fakeDialect) IsDuplicateKey(err error) bool                           { return false }
+func (d *fakeDialect) InsertReturning(column string) string                    { return "" }
+func (d *fakeDialect) UpsertClause(conflictKeys, updateColumns []string) string { return "" }
+func (d *fakeDialect) UpsertReturning(idColumn string) string                  { return "" }
+func (d *fakeDialect) SupportsUpdateJoin() bool                                { return false }
+
+func (d *fakeDialect) DatePartExpr(part, columnRef string) string {
+	return fmt.Sprintf("DATEPART(%s,%s)", part, columnRef)
+}
+
+// fakeOperator supports just the lookups these tests exercise.
+type fakeOperator struct{}
+
+func (o *fakeOperator) OperatorSQL(operator string) string {
+	switch operator {
+	case "exact":
+		return "="
+	case "gt":
+		return ">"
+	default:
+		return "="
+	}
+}
+
+func newTestQuerySet(positional bool, relations RelationConfig) *QuerySetImpl {
+	return NewQuerySet(&fakeOperator{}, &fakeDialect{positional: positional}, relations).(*QuerySetImpl)
+}
+
+func TestGetQuerySet_PlaceholderOrderMatchesArgOrder(t *testing.T) {
+	// chunk0-1 regression: on Postgres, Exclude({"a":1}).Filter({"b":2})
+	// used to number placeholders in call order (a=$1, b=$2) but GetQuerySet
+	// always concatenates filter clauses before exclude clauses, so the
+	// rendered SQL's $1/$2 no longer lined up with the args slice.
+	qs := newTestQuerySet(false, RelationConfig{})
+	qs.ExcludeToSQL(map[string]any{"a": 1})
+	qs.FilterToSQL(map[string]any{"b": 2})
+
+	sql, args := qs.GetQuerySet()
+
+	const want = " WHERE (`b`=$1) AND NOT (`a`=$2)"
+	if sql != want {
+		t.Fatalf("sql = %q, want %q", sql, want)
+	}
+	if len(args) != 2 || args[0] != 2 || args[1] != 1 {
+		t.Fatalf("args = %v, want [2 1]", args)
+	}
+}
+
+func TestGetQuerySet_PositionalDialectUnaffected(t *testing.T) {
+	// MySQL/SQLite's "?" is order-independent, so the same call order must
+	// still produce a correct (if textually different) WHERE clause.
+	qs := newTestQuerySet(true, RelationConfig{})
+	qs.ExcludeToSQL(map[string]any{"a": 1})
+	qs.FilterToSQL(map[string]any{"b": 2})
+
+	sql, args := qs.GetQuerySet()
+
+	const want = " WHERE (`b`=?) AND NOT (`a`=?)"
+	if sql != want {
+		t.Fatalf("sql = %q, want %q", sql, want)
+	}
+	if len(args) != 2 || args[0] != 2 || args[1] != 1 {
+		t.Fatalf("args = %v, want [2 1]", args)
+	}
+}
+
+func TestResolveFieldRef_QualifiesBaseFieldOnlyWhenJoined(t *testing.T) {
+	relations := RelationConfig{
+		BaseTable: BaseAlias,
+		Relations: RelationMap{
+			"user": Relation{FK: "user_id", To: "User", Table: "user"},
+		},
+	}
+
+	t.Run("no join", func(t *testing.T) {
+		qs := newTestQuerySet(true, RelationConfig{})
+		qs.FilterToSQL(map[string]any{"name": "a"})
+		sql, _ := qs.GetQuerySet()
+		const want = " WHERE (`name`=?)"
+		if sql != want {
+			t.Fatalf("sql = %q, want %q", sql, want)
+		}
+	})
+
+	t.Run("joined query qualifies base column", func(t *testing.T) {
+		// chunk0-4 regression: filtering on a base column alongside a
+		// relation hop used to render the base column bare (e.g. `id`),
+		// which is ambiguous once the joined table has a same-named column.
+		qs := newTestQuerySet(true, relations)
+		qs.FilterToSQL(map[string]any{"name": "a", "user__name": "b"})
+		sql, args := qs.GetQuerySet()
+
+		if len(args) != 2 {
+			t.Fatalf("args = %v, want 2 args", args)
+		}
+		wantBase := "T0.`name`="
+		wantJoined := "T1.`name`="
+		if !strings.Contains(sql, wantBase) {
+			t.Fatalf("sql = %q, want it to contain %q", sql, wantBase)
+		}
+		if !strings.Contains(sql, wantJoined) {
+			t.Fatalf("sql = %q, want it to contain %q", sql, wantJoined)
+		}
+	})
+}
+
+func TestRegisterJoin_DefaultsToLeftHonorsExplicitJoinType(t *testing.T) {
+	cases := []struct {
+		name     string
+		joinType string
+		want     string
+	}{
+		{"default is LEFT", "", " LEFT JOIN"},
+		{"explicit INNER", "INNER", " INNER JOIN"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			relations := RelationConfig{
+				BaseTable: BaseAlias,
+				Relations: RelationMap{
+					"user": Relation{FK: "user_id", To: "User", Table: "user", JoinType: c.joinType},
+				},
+			}
+			qs := newTestQuerySet(true, relations)
+			qs.FilterToSQL(map[string]any{"user__name": "a"})
+
+			join := qs.GetJoinSQL()
+			if !strings.Contains(join, c.want) {
+				t.Fatalf("join SQL = %q, want it to contain %q", join, c.want)
+			}
+		})
+	}
+}
+
+func TestHasToManyJoin(t *testing.T) {
+	cases := []struct {
+		name string
+		many bool
+		want bool
+	}{
+		{"belongs-to join", false, false},
+		{"has-many join", true, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			relations := RelationConfig{
+				BaseTable: BaseAlias,
+				Relations: RelationMap{
+					"orders": Relation{FK: "user_id", To: "Order", Table: "order", Many: c.many},
+				},
+			}
+			qs := newTestQuerySet(true, relations)
+			qs.FilterToSQL(map[string]any{"orders__status": "paid"})
+
+			if got := qs.HasToManyJoin(); got != c.want {
+				t.Fatalf("HasToManyJoin() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+)
+
+type dialect struct{}
+
+func NewDialect() *dialect {
+	return &dialect{}
+}
+
+func (d *dialect) QuoteIdentifier(name string) string {
+	return `"` + name + `"`
+}
+
+func (d *dialect) Placeholder(_ int) string {
+	return "?"
+}
+
+func (d *dialect) LimitOffset(limit, offset int64) string {
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+}
+
+func (d *dialect) IsDuplicateKey(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+func (d *dialect) InsertReturning(_ string) string {
+	return ""
+}
+
+func (d *dialect) UpsertClause(conflictKeys, updateColumns []string) string {
+	keys := make([]string, len(conflictKeys))
+	for i, k := range conflictKeys {
+		keys[i] = d.QuoteIdentifier(k)
+	}
+
+	if len(updateColumns) == 0 {
+		return fmt.Sprintf(" ON CONFLICT (%s) DO NOTHING", strings.Join(keys, ","))
+	}
+
+	sets := make([]string, len(updateColumns))
+	for i, c := range updateColumns {
+		q := d.QuoteIdentifier(c)
+		sets[i] = fmt.Sprintf("%s=excluded.%s", q, c)
+	}
+	return fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(keys, ","), strings.Join(sets, ","))
+}
+
+func (d *dialect) UpsertReturning(_ string) string {
+	// SQLite has no MVCC xmax equivalent to distinguish insert from
+	// update in the RETURNING row, so Upsert falls back to the
+	// RowsAffected()==1 heuristic for this dialect too.
+	return ""
+}
+
+// datePartFormats maps a "year"/"month"/"day"/"week_day" lookup part to the
+// strftime format that extracts it. week_day follows strftime's own %w
+// numbering (0=Sunday..6=Saturday), not Django's.
+var datePartFormats = map[string]string{
+	"year": "%Y", "month": "%m", "day": "%d", "week_day": "%w",
+}
+
+func (d *dialect) DatePartExpr(part, columnRef string) string {
+	return fmt.Sprintf("CAST(strftime('%s', %s) AS INTEGER)", datePartFormats[part], columnRef)
+}
+
+func (d *dialect) SupportsUpdateJoin() bool {
+	// SQLite has no UPDATE ... JOIN syntax, so a joined query set is
+	// rejected instead of rendering invalid SQL.
+	return false
+}
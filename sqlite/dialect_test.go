@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDialect_Placeholder(t *testing.T) {
+	d := NewDialect()
+	if got := d.Placeholder(1); got != "?" {
+		t.Fatalf("Placeholder(1) = %q, want %q", got, "?")
+	}
+	if got := d.Placeholder(12); got != "?" {
+		t.Fatalf("Placeholder(12) = %q, want %q", got, "?")
+	}
+}
+
+func TestDialect_UpsertClause(t *testing.T) {
+	d := NewDialect()
+
+	cases := []struct {
+		name          string
+		conflictKeys  []string
+		updateColumns []string
+		want          string
+	}{
+		{
+			name:         "no update columns does nothing",
+			conflictKeys: []string{"id"},
+			want:         ` ON CONFLICT ("id") DO NOTHING`,
+		},
+		{
+			name:          "update columns set against excluded",
+			conflictKeys:  []string{"id"},
+			updateColumns: []string{"name"},
+			want:          ` ON CONFLICT ("id") DO UPDATE SET "name"=excluded.name`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := d.UpsertClause(c.conflictKeys, c.updateColumns)
+			if got != c.want {
+				t.Fatalf("UpsertClause() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestDialect_UpsertReturning(t *testing.T) {
+	d := NewDialect()
+	if got := d.UpsertReturning("id"); got != "" {
+		t.Fatalf("UpsertReturning(\"id\") = %q, want empty string", got)
+	}
+}
+
+func TestDialect_DatePartExpr(t *testing.T) {
+	d := NewDialect()
+
+	cases := []struct {
+		part string
+		want string
+	}{
+		{"year", `CAST(strftime('%Y', "created_at") AS INTEGER)`},
+		{"week_day", `CAST(strftime('%w', "created_at") AS INTEGER)`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.part, func(t *testing.T) {
+			got := d.DatePartExpr(c.part, `"created_at"`)
+			if got != c.want {
+				t.Fatalf("DatePartExpr(%q) = %q, want %q", c.part, got, c.want)
+			}
+		})
+	}
+}
+
+func TestDialect_SupportsUpdateJoin(t *testing.T) {
+	d := NewDialect()
+	if d.SupportsUpdateJoin() {
+		t.Fatal("SupportsUpdateJoin() = true, want false")
+	}
+}
+
+func TestDialect_IsDuplicateKey(t *testing.T) {
+	d := NewDialect()
+	if d.IsDuplicateKey(nil) {
+		t.Fatal("IsDuplicateKey(nil) = true, want false")
+	}
+	if !d.IsDuplicateKey(errors.New("UNIQUE constraint failed: user.email")) {
+		t.Fatal("IsDuplicateKey(UNIQUE constraint error) = false, want true")
+	}
+	if d.IsDuplicateKey(errors.New("some other error")) {
+		t.Fatal("IsDuplicateKey(unrelated error) = true, want false")
+	}
+}
@@ -0,0 +1,35 @@
+package sqlite
+
+var operators = map[string]string{
+	"exact":       "=",
+	"exclude":     "!=",
+	"iexact":      "LIKE",
+	"contains":    "GLOB",
+	"icontains":   "LIKE",
+	"gt":          ">",
+	"gte":         ">=",
+	"lt":          "<",
+	"lte":         "<=",
+	"startswith":  "GLOB",
+	"endswith":    "GLOB",
+	"istartswith": "LIKE",
+	"iendswith":   "LIKE",
+	"in":          "IN",
+	"between":     "BETWEEN",
+	// SQLite has no built-in REGEXP function; it invokes one a driver
+	// registers under that name (e.g. mattn/go-sqlite3's RegisterFunc), and
+	// has no separate case-insensitive form, so "regex"/"iregex" both
+	// render the same operator.
+	"regex":  "REGEXP",
+	"iregex": "REGEXP",
+}
+
+type operator struct{}
+
+func NewOperator() *operator {
+	return &operator{}
+}
+
+func (d *operator) OperatorSQL(operator string) string {
+	return operators[operator]
+}